@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+
+	"github.com/FrangipaneTeam/crown/handlers/status"
+	"github.com/FrangipaneTeam/crown/pkg/db"
+	"github.com/FrangipaneTeam/crown/pkg/ghclient"
+	"github.com/FrangipaneTeam/crown/pkg/owners"
+	"github.com/FrangipaneTeam/crown/pkg/slashcommand"
+	"github.com/FrangipaneTeam/crown/pkg/statustype"
+)
+
+// unownedFiles returns the files changed by the pull request which are not owned by the user.
+// The owners of a file are the approvers of the OWNERS files, or its code owners if there is none.
+func unownedFiles(ghc *ghclient.GHClient, login string) ([]string, error) {
+	files, err := ghc.ListFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	co, err := owners.LoadCodeOwners(ghc)
+	if err != nil && !errors.Is(err, owners.ErrCodeOwnersNotFound) {
+		return nil, err
+	}
+	of := owners.NewOwnersFiles(ghc)
+
+	unowned := make([]string, 0)
+	for _, f := range files {
+		list, err := of.Approvers(f)
+		if err != nil {
+			return nil, err
+		}
+		if len(list) == 0 && co != nil {
+			list = co.Owners(f)
+		}
+		if !isOwner(ghc, list, login) {
+			unowned = append(unowned, f)
+		}
+	}
+
+	return unowned, nil
+}
+
+// setApprovedStatus sets the approved status of the commit from the record of the pull request.
+func setApprovedStatus(ghc *ghclient.GHClient, commitSHA string, e db.Event) error {
+	s := status.NewStatus(ghc, status.PR_Approved, commitSHA)
+	if s == nil {
+		return fmt.Errorf("failed to create status")
+	}
+
+	if e.ApprovedBy == "" {
+		// The new status is pending
+		return nil
+	}
+
+	return s.SetStateWithDescription(statustype.Success, fmt.Sprintf("Approved by @%s", e.ApprovedBy))
+}
+
+// CheckApproval sets the approved status of the pull request.
+// The approval is kept on new commits as long as the approver owns all the changed files.
+func (core *corePR) CheckApproval() {
+	if core.dbEvent.ApprovedBy != "" && core.dbEvent.ApprovedHeadSHA != core.commitSHA {
+		unowned, err := unownedFiles(core.ghc, core.dbEvent.ApprovedBy)
+		switch {
+		case err != nil:
+			core.ghc.Logger.Error().Err(err).Msg("Failed to check the owners of the files")
+		case len(unowned) > 0:
+			core.ghc.Logger.Debug().Msgf("Approval of %s removed, %d files are not owned", core.dbEvent.ApprovedBy, len(unowned))
+			core.dbEvent.ApprovedBy = ""
+			core.dbEvent.ApprovedHeadSHA = ""
+		default:
+			core.dbEvent.ApprovedHeadSHA = core.commitSHA
+		}
+	}
+
+	if err := setApprovedStatus(core.ghc, core.commitSHA, core.dbEvent); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+	}
+}
+
+// ResetLGTM removes the lgtm of the pull request when new commits are pushed (the head has changed).
+// The label is removed by ComputeLabels.
+func (core *corePR) ResetLGTM() {
+	if core.dbEvent.LGTMBy == "" || core.dbEvent.LGTMHeadSHA == core.commitSHA {
+		return
+	}
+
+	if core.dbEvent.LGTMReviewID != 0 {
+		if err := core.ghc.DismissReview(core.dbEvent.LGTMReviewID, "New commits were pushed, /lgtm is required again"); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to dismiss review")
+		}
+	}
+
+	core.dbEvent.UserUnlabeled(core.config.Approval.GetLGTMLabel())
+	core.dbEvent.LGTMBy = ""
+	core.dbEvent.LGTMReviewID = 0
+	core.dbEvent.LGTMHeadSHA = ""
+}
+
+// cmdLGTM adds the lgtm label and approves the pull request on behalf of the reviewer.
+func (core *coreIssueComment) cmdLGTM(inv slashcommand.Invocation) error {
+	if !core.config.Approval.Enabled {
+		return slashcommand.NewUsageError("the approval workflow is disabled")
+	}
+
+	if len(inv.Args) == 1 {
+		if !strings.EqualFold(inv.Args[0], "cancel") {
+			return slashcommand.NewUsageError("unknown argument `%s`", inv.Args[0])
+		}
+		return core.cancelLGTM()
+	}
+
+	login := core.event.GetComment().GetUser().GetLogin()
+	if strings.EqualFold(core.event.GetIssue().GetUser().GetLogin(), login) {
+		return slashcommand.NewUsageError("the author of the pull request cannot /lgtm it")
+	}
+	if core.dbEvent.LGTMBy != "" {
+		// Already looks good
+		return nil
+	}
+
+	pr, err := core.ghc.FetchPullRequest()
+	if err != nil {
+		return err
+	}
+
+	label := core.config.Approval.GetLGTMLabel()
+	if _, err := core.ghc.GetLabel(label); err != nil {
+		if err := core.ghc.CreateLabel(github.Label{
+			Name:        github.String(label),
+			Color:       github.String("15dd18"),
+			Description: github.String("Looks good to me"),
+		}); err != nil {
+			return err
+		}
+	}
+	if err := core.ghc.AddLabelToIssue(label); err != nil {
+		return err
+	}
+
+	reviewID, err := core.ghc.CreateReview("APPROVE", fmt.Sprintf("/lgtm from @%s", login))
+	if err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to create review")
+	}
+
+	core.dbEvent.UserLabeled(label)
+	core.dbEvent.LGTMBy = login
+	core.dbEvent.LGTMReviewID = reviewID
+	core.dbEvent.LGTMHeadSHA = pr.GetHead().GetSHA()
+
+	return core.WriteDB()
+}
+
+// cancelLGTM removes the lgtm label and dismisses the review.
+func (core *coreIssueComment) cancelLGTM() error {
+	if core.dbEvent.LGTMBy == "" {
+		return slashcommand.NewUsageError("the pull request has no lgtm")
+	}
+
+	label := core.config.Approval.GetLGTMLabel()
+	if err := core.ghc.RemoveLabelForIssue(label); err != nil {
+		return err
+	}
+
+	if core.dbEvent.LGTMReviewID != 0 {
+		if err := core.ghc.DismissReview(core.dbEvent.LGTMReviewID, fmt.Sprintf("/lgtm cancelled by @%s", core.event.GetComment().GetUser().GetLogin())); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to dismiss review")
+		}
+	}
+
+	core.dbEvent.UserUnlabeled(label)
+	core.dbEvent.LGTMBy = ""
+	core.dbEvent.LGTMReviewID = 0
+	core.dbEvent.LGTMHeadSHA = ""
+
+	return core.WriteDB()
+}
+
+// cmdApprove approves the pull request, the user must own all the changed files.
+func (core *coreIssueComment) cmdApprove(inv slashcommand.Invocation) error {
+	if !core.config.Approval.Enabled {
+		return slashcommand.NewUsageError("the approval workflow is disabled")
+	}
+
+	pr, err := core.ghc.FetchPullRequest()
+	if err != nil {
+		return err
+	}
+
+	login := core.event.GetComment().GetUser().GetLogin()
+	switch {
+	case len(inv.Args) == 1 && strings.EqualFold(inv.Args[0], "cancel"):
+		if core.dbEvent.ApprovedBy == "" {
+			return slashcommand.NewUsageError("the pull request is not approved")
+		}
+		core.dbEvent.ApprovedBy = ""
+		core.dbEvent.ApprovedHeadSHA = ""
+
+	case len(inv.Args) == 1:
+		return slashcommand.NewUsageError("unknown argument `%s`", inv.Args[0])
+
+	default:
+		unowned, err := unownedFiles(core.ghc, login)
+		if err != nil {
+			return err
+		}
+		if len(unowned) > 0 {
+			return slashcommand.NewUsageError("@%s is not an owner of `%s`", login, strings.Join(unowned, "`, `"))
+		}
+		core.dbEvent.ApprovedBy = login
+		core.dbEvent.ApprovedHeadSHA = pr.GetHead().GetSHA()
+	}
+
+	if err := core.WriteDB(); err != nil {
+		return err
+	}
+
+	return setApprovedStatus(core.ghc, pr.GetHead().GetSHA(), core.dbEvent)
+}
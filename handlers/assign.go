@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/FrangipaneTeam/crown/pkg/slashcommand"
+)
+
+// target is a user or a team (org/team) of /assign and /cc.
+type target struct {
+	user string
+	org  string
+	team string
+}
+
+// parseTargets returns the targets of the arguments (ex: @user, @org/team).
+// Without arguments, the target is the user of the comment.
+func (core *coreIssueComment) parseTargets(args []string, allowTeams bool) ([]target, error) {
+	if len(args) == 0 {
+		return []target{{user: core.event.GetComment().GetUser().GetLogin()}}, nil
+	}
+
+	targets := make([]target, 0, len(args))
+	for _, arg := range args {
+		name := strings.TrimPrefix(strings.TrimSuffix(arg, ","), "@")
+		if name == "" {
+			return nil, slashcommand.NewUsageError("`%s` is not a valid user", arg)
+		}
+
+		if org, team, ok := strings.Cut(name, "/"); ok {
+			if !allowTeams {
+				return nil, slashcommand.NewUsageError("`%s` is a team, only users can be assigned", arg)
+			}
+			if !strings.EqualFold(org, core.ghc.GetRepoOwner()) || team == "" {
+				return nil, slashcommand.NewUsageError("`%s` is not a team of %s", arg, core.ghc.GetRepoOwner())
+			}
+			targets = append(targets, target{org: org, team: team})
+			continue
+		}
+
+		targets = append(targets, target{user: name})
+	}
+
+	return targets, nil
+}
+
+// validateTargets checks that the users are collaborators and the teams have access to the repository.
+func (core *coreIssueComment) validateTargets(targets []target) error {
+	for _, t := range targets {
+		if t.team != "" {
+			ok, err := core.ghc.IsTeamRepository(t.org, t.team)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return slashcommand.NewUsageError("team `%s/%s` has no access to the repository", t.org, t.team)
+			}
+			continue
+		}
+
+		ok, err := core.ghc.IsCollaborator(t.user)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return slashcommand.NewUsageError("`%s` is not a collaborator of the repository", t.user)
+		}
+	}
+
+	return nil
+}
+
+// splitTargets returns the users and the team slugs of the targets.
+func splitTargets(targets []target) (users, teams []string) {
+	for _, t := range targets {
+		if t.team != "" {
+			teams = append(teams, t.team)
+		} else {
+			users = append(users, t.user)
+		}
+	}
+	return users, teams
+}
+
+// cmdAssign assigns the users to the issue.
+func (core *coreIssueComment) cmdAssign(inv slashcommand.Invocation) error {
+	targets, err := core.parseTargets(inv.Args, false)
+	if err != nil {
+		return err
+	}
+	if err := core.validateTargets(targets); err != nil {
+		return err
+	}
+
+	users, _ := splitTargets(targets)
+	return core.ghc.AddAssignees(users)
+}
+
+// cmdUnassign removes the users from the assignees of the issue.
+// The users are not validated, a former collaborator can be unassigned.
+func (core *coreIssueComment) cmdUnassign(inv slashcommand.Invocation) error {
+	targets, err := core.parseTargets(inv.Args, false)
+	if err != nil {
+		return err
+	}
+
+	users, _ := splitTargets(targets)
+	return core.ghc.RemoveAssignees(users)
+}
+
+// cmdCC requests a review from the users and the teams.
+func (core *coreIssueComment) cmdCC(inv slashcommand.Invocation) error {
+	targets, err := core.parseTargets(inv.Args, true)
+	if err != nil {
+		return err
+	}
+
+	author := core.event.GetIssue().GetUser().GetLogin()
+	for _, t := range targets {
+		if strings.EqualFold(t.user, author) {
+			return slashcommand.NewUsageError("the author of the pull request cannot review it")
+		}
+	}
+
+	if err := core.validateTargets(targets); err != nil {
+		return err
+	}
+
+	users, teams := splitTargets(targets)
+	return core.ghc.RequestReviewers(users, teams)
+}
+
+// cmdUnCC removes the review requests of the users and the teams.
+func (core *coreIssueComment) cmdUnCC(inv slashcommand.Invocation) error {
+	targets, err := core.parseTargets(inv.Args, true)
+	if err != nil {
+		return err
+	}
+
+	users, teams := splitTargets(targets)
+	return core.ghc.RemoveReviewers(users, teams)
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/FrangipaneTeam/crown/handlers/comments"
+	"github.com/FrangipaneTeam/crown/pkg/cherrypick"
+	"github.com/FrangipaneTeam/crown/pkg/conventionalcommit"
+	"github.com/FrangipaneTeam/crown/pkg/ghclient"
+	"github.com/FrangipaneTeam/crown/pkg/slashcommand"
+)
+
+// replyCherryPick posts the result of the cherry-pick to the branch on the pull request.
+func replyCherryPick(ghc *ghclient.GHClient, branch, result string) {
+	MsgPRCherryPick := comments.NewCommentMsg(ghc, comments.IDPRCherryPick, comments.PRCherryPickValues{
+		Branch: branch,
+		Result: result,
+	})
+	if MsgPRCherryPick == nil {
+		ghc.Logger.Error().Msg("Failed to create comment")
+		return
+	}
+	if err := MsgPRCherryPick.EditIssueComment(); err != nil {
+		ghc.Logger.Error().Err(err).Msg("Failed to edit issue comment")
+	}
+}
+
+// cherryPick cherry-picks the merged pull request on the target branch and opens a pull request.
+// The result, or the conflict, is posted on the merged pull request.
+func cherryPick(ghc *ghclient.GHClient, pr *github.PullRequest, target, requester string) error {
+	commits, err := cherrypick.Commits(ghc, pr)
+	if err != nil {
+		return err
+	}
+
+	branch := fmt.Sprintf("cherry-pick-%d-to-%s", pr.GetNumber(), target)
+	if b, err := ghc.GetBranch(branch); err != nil {
+		return err
+	} else if b != nil {
+		replyCherryPick(ghc, target, fmt.Sprintf("the branch `%s` already exists, delete it to retry.", branch))
+		return nil
+	}
+
+	if err := cherrypick.CherryPick(ghc, target, branch, commits); err != nil {
+		cerr, ok := cherrypick.AsConflict(err)
+		if !ok {
+			return err
+		}
+
+		replyCherryPick(ghc, target, fmt.Sprintf("the commit %s does not apply cleanly, please cherry-pick it manually :\n```\ngit fetch origin %s\ngit checkout -b %s origin/%s\ngit cherry-pick -x %s\n```", cerr.SHA, target, branch, target, cerr.SHA))
+		return nil
+	}
+
+	// The original title is kept, it is a conventional commit
+	newPR, err := ghc.CreatePullRequest(
+		conventionalcommit.TrimWIP(pr.GetTitle()),
+		branch,
+		target,
+		fmt.Sprintf("Cherry-pick of #%d to `%s`, requested by @%s.", pr.GetNumber(), target, requester),
+	)
+	if err != nil {
+		if derr := ghc.DeleteBranch(branch); derr != nil {
+			ghc.Logger.Error().Err(derr).Str("branch", branch).Msg("Failed to delete branch")
+		}
+		return err
+	}
+
+	replyCherryPick(ghc, target, fmt.Sprintf("#%d opened.", newPR.GetNumber()))
+	return nil
+}
+
+// cmdCherryPick cherry-picks the pull request on the branch.
+// The cherry-pick of a pull request which is not merged yet is run once it is merged.
+func (core *coreIssueComment) cmdCherryPick(inv slashcommand.Invocation) error {
+	target := inv.Args[0]
+	login := core.event.GetComment().GetUser().GetLogin()
+
+	b, err := core.ghc.GetBranch(target)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return slashcommand.NewUsageError("branch `%s` does not exist", target)
+	}
+
+	pr, err := core.ghc.FetchPullRequest()
+	if err != nil {
+		return err
+	}
+	if target == pr.GetBase().GetRef() {
+		return slashcommand.NewUsageError("`%s` is the base branch of the pull request", target)
+	}
+
+	switch {
+	case pr.GetMerged():
+		return cherryPick(core.ghc, pr, target, login)
+
+	case pr.GetState() == "closed":
+		return slashcommand.NewUsageError("the pull request is closed without being merged")
+
+	default:
+		if core.dbEvent.CherryPicks == nil {
+			core.dbEvent.CherryPicks = make(map[string]string)
+		}
+		core.dbEvent.CherryPicks[target] = login
+		if err := core.WriteDB(); err != nil {
+			return err
+		}
+
+		replyCherryPick(core.ghc, target, "scheduled, the pull request will be cherry-picked once merged.")
+		return nil
+	}
+}
+
+// CherryPicks runs the cherry-picks requested before the merge of the pull request.
+func (core *corePR) CherryPicks(cherryPicks map[string]string) {
+	for target, requester := range cherryPicks {
+		if err := cherryPick(core.ghc, core.event.GetPullRequest(), target, requester); err != nil {
+			core.ghc.Logger.Error().Err(err).Str("branch", target).Msg("Failed to cherry-pick")
+			replyCherryPick(core.ghc, target, "failed, please retry with `/cherry-pick "+target+"`.")
+		}
+	}
+}
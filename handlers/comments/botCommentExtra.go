@@ -8,6 +8,9 @@ const (
 	ExtraBotID BotCommentExtra = 48753691 << iota
 	ExtraBotLabel
 	ExtraCommitID
+	ExtraTrackTarget
+	ExtraCherryPickTarget
+	ExtraReplyTo
 	// ! Always add new IDs at the END of the list.
 )
 
@@ -33,3 +36,94 @@ type IssuesTitleInvalidValues struct {
 	Title string
 	Scope string
 }
+
+type IssuesTrackConfirmedValues struct {
+	Target string
+}
+
+type IssuesTemplateFieldsMissingValues struct {
+	Template string
+	Fields   []string
+}
+
+type PRDescriptionInvalidValues struct {
+	Template string
+	Problems []string
+}
+
+type PRLinkedIssueMissingValues struct {
+	Type     string
+	Problems []string
+}
+
+type PRDCOInvalidValues struct {
+	// Commits is the list of the commits without sign-off
+	Commits []string
+	// NumberOfCommits is the number of commits of the PR
+	NumberOfCommits int
+}
+
+type PRSignatureInvalidValues struct {
+	// Commits is the list of the commits without verified signature and the reason
+	Commits []string
+}
+
+type PRBranchInvalidValues struct {
+	// Branch is the name of the head branch
+	Branch string
+	// Problems is the list of the problems found
+	Problems []string
+	// Patterns is the list of the allowed formats
+	Patterns []string
+	// Types is the list of the allowed types
+	Types []string
+}
+
+type SlashCommandInvalidValues struct {
+	// ReplyTo identifies the command replied to, each command gets its own reply
+	ReplyTo string
+	// User is the login of the user who ran the command
+	User string
+	// Command is the line of the command
+	Command string
+	// Error is the reason
+	Error string
+	// Usage is the usage of the command
+	Usage string
+}
+
+type SlashCommandHelpValues struct {
+	// ReplyTo identifies the command replied to, each command gets its own reply
+	ReplyTo string
+	// User is the login of the user who ran the command
+	User string
+	// Help is the list of the commands
+	Help string
+}
+
+type SlashCommandRefusedValues struct {
+	// ReplyTo identifies the command replied to, each command gets its own reply
+	ReplyTo string
+	// User is the login of the user
+	User string
+	// Command is the line of the command
+	Command string
+	// Allowed describes who can run the command
+	Allowed string
+}
+
+type PRRetitleSuggestionValues struct {
+	// ReplyTo identifies the command replied to, each command gets its own reply
+	ReplyTo string
+	// User is the login of the user who ran the command
+	User string
+	// Title is the suggested title
+	Title string
+}
+
+type PRCherryPickValues struct {
+	// Branch is the target branch of the cherry-pick
+	Branch string
+	// Result describes the result of the cherry-pick
+	Result string
+}
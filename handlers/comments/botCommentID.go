@@ -11,6 +11,18 @@ const (
 	IDPRTitleInvalid
 	IDPRCommitInvalid
 	IDPRSizeTooBig
+	IDIssuesTrackConfirmed
+	IDIssuesTemplateFieldsMissing
+	IDPRDescriptionInvalid
+	IDPRLinkedIssueMissing
+	IDPRDCOInvalid
+	IDPRSignatureInvalid
+	IDPRBranchInvalid
+	IDSlashCommandInvalid
+	IDSlashCommandHelp
+	IDSlashCommandRefused
+	IDPRRetitleSuggestion
+	IDPRCherryPick
 	// ! Always add new IDs at the END of the list.
 )
 
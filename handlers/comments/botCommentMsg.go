@@ -3,6 +3,7 @@ package comments
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/google/go-github/v47/github"
 
@@ -15,15 +16,32 @@ var (
 		IDIssuesTitleInvalid:   "The issue title `%s` is not conventional issue format.\nPlease follow this format : `[SCOPE] title`",
 		IDIssuesLabelNotExists: "The label `%s` not existing in this repository. \nif you are an administrator you can write a comment with the command `/label:add %s` to automatically create the label",
 		// PR comments
-		IDPRTitleInvalid:  "The pull request title `%s` is not conventional commit format.\nPlease follow this format : `type(scope): subject` or `type: subject`\n\nFor more information about conventional commit, please visit [conventionalcommits.org](https://www.conventionalcommits.org/en/v1.0.0/)",
-		IDPRCommitInvalid: "The commit message `%s` is not conventional commit format.\nPlease follow this formats :\n* `type(scope): subject`\n* `type: subject`\n\nFor more information about conventional commit, please visit [conventionalcommits.org](https://www.conventionalcommits.org/en/v1.0.0/)",
-		IDPRSizeTooBig:    "Thank you for your contribution, but this PR exceeds the recommended size of 1000 lines. Please make sure you are NOT addressing multiple issues with one PR.\nNote this PR might be rejected due to its size.",
+		IDPRTitleInvalid:       "The pull request title `%s` is not conventional commit format.\nPlease follow this format : `type(scope): subject` or `type: subject`\n\nFor more information about conventional commit, please visit [conventionalcommits.org](https://www.conventionalcommits.org/en/v1.0.0/)",
+		IDPRCommitInvalid:      "The commit message `%s` is not conventional commit format.\nPlease follow this formats :\n* `type(scope): subject`\n* `type: subject`\n\nFor more information about conventional commit, please visit [conventionalcommits.org](https://www.conventionalcommits.org/en/v1.0.0/)",
+		IDPRDescriptionInvalid: "The pull request description does not follow the template `%s` :\n%s\n\nPlease edit the description of the pull request.",
+		IDPRLinkedIssueMissing: "Pull requests of type `%s` must be linked to an open issue.\nPlease add a closing keyword in the description (`Closes #12`) or a footer in a commit message (`Refs: #12`).%s",
+		IDPRDCOInvalid:         "The following commits are not signed off ([Developer Certificate of Origin](https://developercertificate.org/)) or the sign-off does not match the commit author :\n%s\n\nTo sign off the last commit :\n```\ngit commit --amend -s --no-edit\ngit push --force-with-lease\n```\nTo sign off all the commits of this pull request :\n```\ngit rebase --signoff HEAD~%d\ngit push --force-with-lease\n```\nA maintainer can override this check with the command `/dco:override`.",
+		IDPRSignatureInvalid:   "The following commits do not have a verified signature :\n%s\n\nMore details about commit signature verification : https://docs.github.com/en/authentication/managing-commit-signature-verification/about-commit-signature-verification",
+		IDPRBranchInvalid:      "The branch name `%s` does not respect the naming policy :\n%s\n\nAllowed formats :\n%s\n\nAllowed types :\n%s",
+		IDSlashCommandInvalid:  "@%s the command `%s` is invalid : %s.\nUsage : `%s`",
+		IDSlashCommandHelp:     "@%s available commands :\n\n%s\nA command must start a line, the arguments with spaces must be quoted.",
+		IDSlashCommandRefused:  "Sorry @%s, you are not allowed to run `%s`.\nThis command can be run by %s.",
+		IDPRRetitleSuggestion:  "@%s suggested title, derived from the commits of this pull request :\n```\n%s\n```\nApply it with `/retitle %s`",
+		IDPRCherryPick:         "Cherry-pick to `%s` : %s",
+		IDPRSizeTooBig:         "Thank you for your contribution, but this PR exceeds the recommended size of 1000 lines. Please make sure you are NOT addressing multiple issues with one PR.\nNote this PR might be rejected due to its size.",
+		// Tracker comments
+		IDIssuesTrackConfirmed: "This issue now tracks `%s`.\nA comment will be posted here when the tracked issue is updated or closed.",
+		// Issue forms comments
+		IDIssuesTemplateFieldsMissing: "This issue was opened with the template `%s` but the following required fields are not filled :\n%s\n\nPlease edit the issue to fill them in.",
 	}
 
 	issuesCommentsExtra = map[BotCommentExtra]commentExtra{
-		ExtraBotID:    {key: "botid", value: nil},
-		ExtraBotLabel: {key: "bot_label", value: nil},
-		ExtraCommitID: {key: "commit_id", value: nil},
+		ExtraBotID:            {key: "botid", value: nil},
+		ExtraBotLabel:         {key: "bot_label", value: nil},
+		ExtraCommitID:         {key: "commit_id", value: nil},
+		ExtraTrackTarget:      {key: "track_target", value: nil},
+		ExtraCherryPickTarget: {key: "cherry_pick_target", value: nil},
+		ExtraReplyTo:          {key: "reply_to", value: nil},
 	}
 )
 
@@ -82,6 +100,27 @@ func (c *commentMsg) setExtra(extra BotCommentExtra, v interface{}) {
 	c.extra[extra] = w
 }
 
+// replyTo keys the comment on the command replied to.
+// A new command gets a new comment instead of editing the reply to a previous one.
+func (c *commentMsg) replyTo(replyTo string) {
+	c.setExtra(ExtraReplyTo, replyTo)
+	c.IsIssueCommentExist = func() (commentID int64, exist bool) {
+		cts, err := c.ghc.ListComments()
+		if err != nil {
+			c.ghc.Logger.Error().Err(err).Msg("Failed to get comments")
+			return 0, false
+		}
+		for _, comment := range cts {
+			if ok, value := ExtraIssueComment(comment.GetBody(), c.id, ExtraBotID); ok && c.id.IsValid(value) {
+				if ok, r := ExtraIssueComment(comment.GetBody(), c.id, ExtraReplyTo); ok && r == replyTo {
+					return comment.GetID(), true
+				}
+			}
+		}
+		return 0, false
+	}
+}
+
 // NewCommentMsg creates a new comment message.
 func NewCommentMsg(ghc *ghclient.GHClient, id BotCommentID, values interface{}) *commentMsg { //nolint:gocyclo,revive
 	x := &commentMsg{
@@ -242,6 +281,204 @@ func NewCommentMsg(ghc *ghclient.GHClient, id BotCommentID, values interface{})
 			return nil
 		}
 
+	case IDIssuesTrackConfirmed:
+		if x.values == nil {
+			x.ghc.Logger.Error().Msg("values is nil")
+			return nil
+		}
+
+		if vals, ok := x.values.(IssuesTrackConfirmedValues); ok {
+			x.setExtra(ExtraTrackTarget, vals.Target)
+
+			x.msgComputed = fmt.Sprintf(issuesComments[id], vals.Target)
+			x.IsIssueCommentExist = func() (commentID int64, exist bool) {
+				cts, err := x.ghc.ListComments()
+				if err != nil {
+					x.ghc.Logger.Error().Err(err).Msg("Failed to get comments")
+					return 0, false
+				}
+				for _, comment := range cts {
+					if ok, value := ExtraIssueComment(comment.GetBody(), id, ExtraBotID); ok && id.IsValid(value) {
+						if ok, target := ExtraIssueComment(comment.GetBody(), id, ExtraTrackTarget); ok && target == vals.Target {
+							return comment.GetID(), true
+						}
+					}
+				}
+				return 0, false
+			}
+		} else {
+			x.ghc.Logger.Error().Msg("values is not IssuesTrackConfirmedValues")
+			return nil
+		}
+
+	case IDIssuesTemplateFieldsMissing:
+		if x.values == nil {
+			x.ghc.Logger.Error().Msg("values is nil")
+			return nil
+		}
+
+		if vals, ok := x.values.(IssuesTemplateFieldsMissingValues); ok {
+			x.msgComputed = fmt.Sprintf(issuesComments[id], vals.Template, markdownList(vals.Fields))
+		} else {
+			x.ghc.Logger.Error().Msg("values is not IssuesTemplateFieldsMissingValues")
+			return nil
+		}
+
+	case IDPRDescriptionInvalid:
+		if x.values == nil {
+			x.ghc.Logger.Error().Msg("values is nil")
+			return nil
+		}
+
+		if vals, ok := x.values.(PRDescriptionInvalidValues); ok {
+			x.msgComputed = fmt.Sprintf(issuesComments[id], vals.Template, "* "+strings.Join(vals.Problems, "\n* "))
+		} else {
+			x.ghc.Logger.Error().Msg("values is not PRDescriptionInvalidValues")
+			return nil
+		}
+
+	case IDPRLinkedIssueMissing:
+		if x.values == nil {
+			x.ghc.Logger.Error().Msg("values is nil")
+			return nil
+		}
+
+		if vals, ok := x.values.(PRLinkedIssueMissingValues); ok {
+			problems := ""
+			if len(vals.Problems) > 0 {
+				problems = "\n\n* " + strings.Join(vals.Problems, "\n* ")
+			}
+			x.msgComputed = fmt.Sprintf(issuesComments[id], vals.Type, problems)
+		} else {
+			x.ghc.Logger.Error().Msg("values is not PRLinkedIssueMissingValues")
+			return nil
+		}
+
+	case IDPRDCOInvalid:
+		if x.values == nil {
+			x.ghc.Logger.Error().Msg("values is nil")
+			return nil
+		}
+
+		if vals, ok := x.values.(PRDCOInvalidValues); ok {
+			x.msgComputed = fmt.Sprintf(issuesComments[id], "* "+strings.Join(vals.Commits, "\n* "), vals.NumberOfCommits)
+		} else {
+			x.ghc.Logger.Error().Msg("values is not PRDCOInvalidValues")
+			return nil
+		}
+
+	case IDPRSignatureInvalid:
+		if x.values == nil {
+			x.ghc.Logger.Error().Msg("values is nil")
+			return nil
+		}
+
+		if vals, ok := x.values.(PRSignatureInvalidValues); ok {
+			x.msgComputed = fmt.Sprintf(issuesComments[id], "* "+strings.Join(vals.Commits, "\n* "))
+		} else {
+			x.ghc.Logger.Error().Msg("values is not PRSignatureInvalidValues")
+			return nil
+		}
+
+	case IDPRBranchInvalid:
+		if x.values == nil {
+			x.ghc.Logger.Error().Msg("values is nil")
+			return nil
+		}
+
+		if vals, ok := x.values.(PRBranchInvalidValues); ok {
+			x.msgComputed = fmt.Sprintf(issuesComments[id], vals.Branch, "* "+strings.Join(vals.Problems, "\n* "), markdownList(vals.Patterns), markdownList(vals.Types))
+		} else {
+			x.ghc.Logger.Error().Msg("values is not PRBranchInvalidValues")
+			return nil
+		}
+
+	case IDSlashCommandInvalid:
+		if x.values == nil {
+			x.ghc.Logger.Error().Msg("values is nil")
+			return nil
+		}
+
+		if vals, ok := x.values.(SlashCommandInvalidValues); ok {
+			x.replyTo(vals.ReplyTo)
+			x.msgComputed = fmt.Sprintf(issuesComments[id], vals.User, vals.Command, vals.Error, vals.Usage)
+		} else {
+			x.ghc.Logger.Error().Msg("values is not SlashCommandInvalidValues")
+			return nil
+		}
+
+	case IDSlashCommandHelp:
+		if x.values == nil {
+			x.ghc.Logger.Error().Msg("values is nil")
+			return nil
+		}
+
+		if vals, ok := x.values.(SlashCommandHelpValues); ok {
+			x.replyTo(vals.ReplyTo)
+			x.msgComputed = fmt.Sprintf(issuesComments[id], vals.User, vals.Help)
+		} else {
+			x.ghc.Logger.Error().Msg("values is not SlashCommandHelpValues")
+			return nil
+		}
+
+	case IDSlashCommandRefused:
+		if x.values == nil {
+			x.ghc.Logger.Error().Msg("values is nil")
+			return nil
+		}
+
+		if vals, ok := x.values.(SlashCommandRefusedValues); ok {
+			x.replyTo(vals.ReplyTo)
+			x.msgComputed = fmt.Sprintf(issuesComments[id], vals.User, vals.Command, vals.Allowed)
+		} else {
+			x.ghc.Logger.Error().Msg("values is not SlashCommandRefusedValues")
+			return nil
+		}
+
+	case IDPRCherryPick:
+		if x.values == nil {
+			x.ghc.Logger.Error().Msg("values is nil")
+			return nil
+		}
+
+		if vals, ok := x.values.(PRCherryPickValues); ok {
+			x.setExtra(ExtraCherryPickTarget, vals.Branch)
+
+			x.msgComputed = fmt.Sprintf(issuesComments[id], vals.Branch, vals.Result)
+			x.IsIssueCommentExist = func() (commentID int64, exist bool) {
+				cts, err := x.ghc.ListComments()
+				if err != nil {
+					x.ghc.Logger.Error().Err(err).Msg("Failed to get comments")
+					return 0, false
+				}
+				for _, comment := range cts {
+					if ok, value := ExtraIssueComment(comment.GetBody(), id, ExtraBotID); ok && id.IsValid(value) {
+						if ok, target := ExtraIssueComment(comment.GetBody(), id, ExtraCherryPickTarget); ok && target == vals.Branch {
+							return comment.GetID(), true
+						}
+					}
+				}
+				return 0, false
+			}
+		} else {
+			x.ghc.Logger.Error().Msg("values is not PRCherryPickValues")
+			return nil
+		}
+
+	case IDPRRetitleSuggestion:
+		if x.values == nil {
+			x.ghc.Logger.Error().Msg("values is nil")
+			return nil
+		}
+
+		if vals, ok := x.values.(PRRetitleSuggestionValues); ok {
+			x.replyTo(vals.ReplyTo)
+			x.msgComputed = fmt.Sprintf(issuesComments[id], vals.User, vals.Title, vals.Title)
+		} else {
+			x.ghc.Logger.Error().Msg("values is not PRRetitleSuggestionValues")
+			return nil
+		}
+
 	default:
 		x.msgComputed = fmt.Sprintf(issuesComments[id])
 	}
@@ -249,6 +486,15 @@ func NewCommentMsg(ghc *ghclient.GHClient, id BotCommentID, values interface{})
 	return x
 }
 
+// markdownList returns the items as a markdown list.
+func markdownList(items []string) string {
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		list = append(list, fmt.Sprintf("* `%s`", item))
+	}
+	return strings.Join(list, "\n")
+}
+
 // createIssueMessage creates a comment on the issue if the title is not conventional issue format.
 func (c *commentMsg) createIssueMessage() *string {
 	var msg string
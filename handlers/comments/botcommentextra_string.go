@@ -11,12 +11,18 @@ func _() {
 	_ = x[ExtraBotID-48753691]
 	_ = x[ExtraBotLabel-97507382]
 	_ = x[ExtraCommitID-195014764]
+	_ = x[ExtraTrackTarget-390029528]
+	_ = x[ExtraCherryPickTarget-780059056]
+	_ = x[ExtraReplyTo-1560118112]
 }
 
 const (
 	_BotCommentExtra_name_0 = "ExtraBotID"
 	_BotCommentExtra_name_1 = "ExtraBotLabel"
 	_BotCommentExtra_name_2 = "ExtraCommitID"
+	_BotCommentExtra_name_3 = "ExtraTrackTarget"
+	_BotCommentExtra_name_4 = "ExtraCherryPickTarget"
+	_BotCommentExtra_name_5 = "ExtraReplyTo"
 )
 
 func (i BotCommentExtra) String() string {
@@ -27,6 +33,12 @@ func (i BotCommentExtra) String() string {
 		return _BotCommentExtra_name_1
 	case i == 195014764:
 		return _BotCommentExtra_name_2
+	case i == 390029528:
+		return _BotCommentExtra_name_3
+	case i == 780059056:
+		return _BotCommentExtra_name_4
+	case i == 1560118112:
+		return _BotCommentExtra_name_5
 	default:
 		return "BotCommentExtra(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
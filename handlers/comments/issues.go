@@ -3,6 +3,8 @@ package comments
 import (
 	"regexp"
 
+	"github.com/google/go-github/v47/github"
+
 	"github.com/FrangipaneTeam/crown/pkg/common"
 	"github.com/FrangipaneTeam/crown/pkg/ghclient"
 )
@@ -43,3 +45,23 @@ func ExtraIssueComment(msgBody string, _ BotCommentID, key BotCommentExtra) (fou
 
 	return false, ""
 }
+
+// ListBotComments returns the comments posted by the bot on the issue.
+func ListBotComments(ghClient *ghclient.GHClient) ([]*github.IssueComment, error) {
+	cts, err := ghClient.ListComments()
+	if err != nil {
+		return nil, err
+	}
+
+	x := make([]*github.IssueComment, 0)
+	for _, comment := range cts {
+		if comment.GetUser().GetType() != "Bot" {
+			continue
+		}
+		if found, _ := ExtraIssueComment(comment.GetBody(), 0, ExtraBotID); found {
+			x = append(x, comment)
+		}
+	}
+
+	return x, nil
+}
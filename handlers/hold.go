@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/FrangipaneTeam/crown/handlers/status"
+	"github.com/FrangipaneTeam/crown/pkg/db"
+	"github.com/FrangipaneTeam/crown/pkg/ghclient"
+	"github.com/FrangipaneTeam/crown/pkg/slashcommand"
+	"github.com/FrangipaneTeam/crown/pkg/statustype"
+)
+
+// maxStatusDescription is the maximum length of the description of a status.
+const maxStatusDescription = 140
+
+// setHoldStatus sets the hold status of the commit from the record of the pull request.
+func setHoldStatus(ghc *ghclient.GHClient, commitSHA string, e db.Event) error {
+	s := status.NewStatus(ghc, status.PR_Hold, commitSHA)
+	if s == nil {
+		return fmt.Errorf("failed to create status")
+	}
+
+	if !e.IsHold() {
+		return s.SetState(statustype.Success)
+	}
+
+	description := fmt.Sprintf("On hold by @%s", e.HoldBy)
+	if e.HoldReason != "" {
+		description += ": " + e.HoldReason
+	}
+	if r := []rune(description); len(r) > maxStatusDescription {
+		description = string(r[:maxStatusDescription-3]) + "..."
+	}
+
+	return s.SetStateWithDescription(statustype.Failure, description)
+}
+
+// cmdHold puts the pull request on hold.
+// The hold is recorded, so the next checks of the pull request keep it.
+func (core *coreIssueComment) cmdHold(inv slashcommand.Invocation) error {
+	core.dbEvent.HoldBy = core.event.GetComment().GetUser().GetLogin()
+	core.dbEvent.HoldReason = inv.Raw
+
+	return core.applyHold()
+}
+
+// cmdUnhold removes the hold of the pull request.
+// The author can only remove their own hold, the hold of someone else requires the triage permission.
+func (core *coreIssueComment) cmdUnhold(_ slashcommand.Invocation) error {
+	if !core.dbEvent.IsHold() {
+		return slashcommand.NewUsageError("the pull request is not on hold")
+	}
+
+	login := core.event.GetComment().GetUser().GetLogin()
+	if !strings.EqualFold(login, core.dbEvent.HoldBy) {
+		ok, err := core.isAllowed(login, slashcommand.Permission{Level: slashcommand.LevelTriage})
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return slashcommand.NewUsageError("the hold was set by @%s, only them or a collaborator with triage permission can remove it", core.dbEvent.HoldBy)
+		}
+	}
+
+	core.dbEvent.HoldBy = ""
+	core.dbEvent.HoldReason = ""
+
+	return core.applyHold()
+}
+
+// applyHold records the hold and sets the status on the head of the pull request.
+func (core *coreIssueComment) applyHold() error {
+	pr, err := core.ghc.FetchPullRequest()
+	if err != nil {
+		return err
+	}
+
+	if err := core.WriteDB(); err != nil {
+		return err
+	}
+
+	return setHoldStatus(core.ghc, pr.GetHead().GetSHA(), core.dbEvent)
+}
@@ -6,22 +6,22 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/azrod/common-go"
 	"github.com/google/go-github/v47/github"
 	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/pkg/errors"
 
 	"github.com/FrangipaneTeam/crown/handlers/comments"
 	"github.com/FrangipaneTeam/crown/handlers/status"
+	"github.com/FrangipaneTeam/crown/pkg/config"
 	"github.com/FrangipaneTeam/crown/pkg/db"
 	"github.com/FrangipaneTeam/crown/pkg/ghclient"
-	"github.com/FrangipaneTeam/crown/pkg/labeler"
 	"github.com/FrangipaneTeam/crown/pkg/slashcommand"
 	"github.com/FrangipaneTeam/crown/pkg/statustype"
 )
 
 type IssueCommentHandler struct {
 	githubapp.ClientCreator
+	Config *config.CrownConfig
 }
 
 // Handles returns the list of events this handler handles.
@@ -30,16 +30,21 @@ func (h *IssueCommentHandler) Handles() []string {
 }
 
 type coreIssueComment struct {
-	ghc            *ghclient.GHClient
-	eDB            *db.EventDB
-	event          github.IssueCommentEvent
+	ghc     *ghclient.GHClient
+	eDB     *db.EventDB
+	config  *config.CrownConfig
+	event   github.IssueCommentEvent
+	dbEvent db.Event
+	// levels is the cache of the permission levels of the users
+	levels map[string]slashcommand.Level
+	// replyTo identifies the slash command being run, the replies are keyed on it
+	replyTo        string
 	labelsCategory *[]string
 	labelsType     *[]string
 
 	PR_Check_Title       *status.Status //nolint:revive,stylecheck
 	PR_Check_commits     *status.Status //nolint:revive,stylecheck
 	PR_Check_SizeChanges *status.Status //nolint:revive,stylecheck
-	PR_Labeler           *status.Status //nolint:revive,stylecheck
 }
 
 // Handle processes the event.
@@ -62,7 +67,9 @@ func (h *IssueCommentHandler) Handle(ctx context.Context, _, _ string, payload [
 	core := &coreIssueComment{
 		ghc:            ghc,
 		eDB:            db.EventDBNew(db.DBEvent),
+		config:         h.Config,
 		event:          event,
+		levels:         make(map[string]slashcommand.Level),
 		labelsCategory: &[]string{},
 		labelsType:     &[]string{},
 	}
@@ -80,71 +87,60 @@ func (h *IssueCommentHandler) Handle(ctx context.Context, _, _ string, payload [
 			core.LoadLabels(dbEvent)
 		}
 	}
+	core.dbEvent = dbEvent
 
 	commentBody := event.GetComment().GetBody()
-	commentID := event.GetComment().GetID()
-	user := event.GetComment().GetUser()
-
-	if ok, _ := ghc.IsInOrganization(user.GetLogin()); ok {
-		if foundSlashCommand, cmd, err := slashcommand.FindSlashCommand(commentBody); foundSlashCommand {
-			switch cmd := cmd.(type) { //nolint:gocritic
-			case slashcommand.Label:
-				ghc.Logger.Debug().Msgf("Found slash command %s with verb %s from %s", cmd.Action, cmd.Verb, user.GetName())
-				switch cmd.Action { //nolint:gocritic
-				case slashcommand.CommandLabel:
-					label := labeler.LabelScope(cmd.Label)
-					switch cmd.Verb {
-					case slashcommand.VerbAdd:
-						if err := ghc.CreateLabel(label.GithubLabel()); err != nil {
-							if err := ghc.AddCommentReaction(commentID, "-1"); err != nil {
-								ghc.Logger.Err(err).Msg("failed to add reaction")
-							}
-							return err
-						}
-
-						if err := ghc.AddCommentReaction(commentID, "+1"); err != nil {
-							ghc.Logger.Err(err).Msg("failed to add reaction")
-							return err
-						}
-
-						MsgPRIssuesLabelNotExists := comments.NewCommentMsg(ghc, comments.IDIssuesLabelNotExists, comments.IssuesLabelNotExistsValues{
-							Label: label.GetLongName(),
-						})
-
-						// Remove comment if label added is in comment
-						if err := MsgPRIssuesLabelNotExists.RemoveIssueComment(); err != nil {
-							ghc.Logger.Err(err).Msg("failed to remove comment")
-						}
-
-						*core.labelsCategory = append(*core.labelsCategory, label.GetLongName())
-
-					case slashcommand.VerbRemove:
-						for i, l := range *core.labelsCategory {
-							if l == label.GetLongName() {
-								*core.labelsCategory = append((*core.labelsCategory)[:i], (*core.labelsCategory)[i+1:]...)
-								break
-							}
-						}
-					}
-				}
-			}
-			core.ComputeLabels()
-		} else if err != nil {
-			return err
-		}
-	} else {
-		ghc.Logger.Debug().Msgf("User %s is not in %s org", user.GetLogin(), ghc.GetOrg())
+
+	// Track the upstream issues referenced in the comment
+	if h.Config.Tracker.AutoTrack && (event.GetAction() == "created" || event.GetAction() == "edited") {
+		autoTrack(ghc, commentBody)
+	}
+
+	// Slash commands are run once, when the comment is created
+	if event.GetAction() == "created" {
+		core.runSlashCommands(commentBody)
 	}
 
 	return nil
 }
 
-// GetLabels return the labels.
-func (core *coreIssueComment) GetLabels() []string {
-	x := make([]string, 0)
-	x = append(x, *core.labelsCategory...)
-	x = append(x, *core.labelsType...)
-	return x
+// OverrideDCO overrides the DCO check of the pull request.
+func (core *coreIssueComment) OverrideDCO(login string) error {
+	pr, err := core.ghc.FetchPullRequest()
+	if err != nil {
+		return err
+	}
+
+	// Record the override, the next checks of the pull request respect it until new commits are pushed
+	core.dbEvent.DCOOverrideBy = login
+	core.dbEvent.DCOOverrideHeadSHA = pr.GetHead().GetSHA()
+	if err := core.WriteDB(); err != nil {
+		return err
+	}
+
+	if err := status.NewStatus(core.ghc, status.PR_Check_DCO, pr.GetHead().GetSHA()).SetStateWithDescription(statustype.Success, fmt.Sprintf("DCO check overridden by @%s", login)); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+	}
+
+	if err := comments.NewCommentMsg(core.ghc, comments.IDPRDCOInvalid, comments.PRDCOInvalidValues{}).RemoveIssueComment(); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to remove issue comment")
+	}
+
+	return nil
+}
+
+// WriteDB Record data in DB.
+func (core *coreIssueComment) WriteDB() error {
+	core.dbEvent.InstallationID = core.ghc.GetInstallationID()
+	core.dbEvent.RepoOwner = core.ghc.GetRepoOwner()
+	core.dbEvent.RepoName = core.ghc.GetRepoName()
+	core.dbEvent.ID = core.event.GetIssue().GetNumber()
+
+	x, err := json.Marshal(core.dbEvent)
+	if err != nil {
+		return err
+	}
+	return core.eDB.Set([]byte(core.PathDB()), x)
 }
 
 // Load labels from DB.
@@ -157,39 +153,3 @@ func (core *coreIssueComment) LoadLabels(d db.Event) {
 func (core *coreIssueComment) PathDB() string {
 	return fmt.Sprintf("%d/%s/%s/%d", core.ghc.GetInstallationID(), core.ghc.GetRepoOwner(), core.ghc.GetRepoName(), core.event.GetIssue().GetNumber())
 }
-
-// ComputeLabels compute labels.
-func (core *coreIssueComment) ComputeLabels() {
-	o := make([]string, 0)
-	allLabels := make([]string, 0)
-	allLabels = append(allLabels, *core.labelsType...)
-	allLabels = append(allLabels, *core.labelsCategory...)
-
-	for _, lbl := range core.event.GetIssue().Labels {
-		core.ghc.Logger.Debug().Msgf("Label is %s", lbl.GetName())
-		if _, ok := common.Find(allLabels, lbl.GetName()); !ok {
-			if err := core.ghc.RemoveLabelForIssue(lbl.GetName()); err != nil {
-				if err := core.PR_Labeler.SetState(statustype.Failure); err != nil {
-					core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
-				}
-				core.ghc.Logger.Error().Err(err).Msg("Failed to remove label")
-			}
-		}
-		o = append(o, lbl.GetName())
-	}
-
-	for _, lbl := range allLabels {
-		if _, ok := common.Find(o, lbl); !ok {
-			if err := core.ghc.AddLabelToIssue(lbl); err != nil {
-				if err := core.PR_Labeler.SetState(statustype.Failure); err != nil {
-					core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
-				}
-				core.ghc.Logger.Error().Err(err).Msg("Failed to add label")
-			}
-		}
-	}
-
-	if err := core.PR_Labeler.IsSuccess(); err != nil {
-		core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
-	}
-}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	"github.com/azrod/common-go"
@@ -11,7 +12,12 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 
+	"github.com/FrangipaneTeam/crown/handlers/comments"
+	"github.com/FrangipaneTeam/crown/pkg/config"
+	"github.com/FrangipaneTeam/crown/pkg/conventionalissue"
+	"github.com/FrangipaneTeam/crown/pkg/db"
 	"github.com/FrangipaneTeam/crown/pkg/ghclient"
+	"github.com/FrangipaneTeam/crown/pkg/issueform"
 	"github.com/FrangipaneTeam/crown/pkg/labeler"
 )
 
@@ -20,6 +26,7 @@ import (
 
 type IssuesHandler struct {
 	githubapp.ClientCreator
+	Config *config.CrownConfig
 }
 
 // Handles returns the list of events this handler handles.
@@ -41,6 +48,7 @@ func (h *IssuesHandler) Handle(ctx context.Context, _, _ string, payload []byte)
 
 	core := &coreIssues{
 		ghc:            ghc,
+		eDB:            db.EventDBNew(db.DBEvent),
 		event:          event,
 		labelsCategory: &[]string{},
 		labelsType:     &[]string{},
@@ -58,15 +66,33 @@ func (h *IssuesHandler) Handle(ctx context.Context, _, _ string, payload []byte)
 		return nil
 	}
 
-	switch event.GetAction() { //nolint:gocritic
-	case "opened":
+	switch event.GetAction() {
+	case "opened", "edited", "reopened":
+
+		core.ReadDB()
 
 		// Author community
 		// core.Community()
+
+		// Check if title respect [SCOPE] title
+		core.CheckTitle()
+		// Check if body respect the issue form
+		core.CheckTemplate()
+
+		core.WriteDB()
+
 		if err := core.ComputeLabels(); err != nil {
 			ghc.Logger.Error().Err(err).Msg("Failed to compute labels")
 			return err
 		}
+
+		// Track the upstream issues referenced in the body
+		if h.Config.Tracker.AutoTrack {
+			autoTrack(core.ghc, core.event.GetIssue().GetBody())
+		}
+
+	default:
+		return nil
 	}
 
 	return nil
@@ -74,11 +100,179 @@ func (h *IssuesHandler) Handle(ctx context.Context, _, _ string, payload []byte)
 
 type coreIssues struct {
 	ghc            *ghclient.GHClient
+	eDB            *db.EventDB
 	event          github.IssuesEvent
+	dbEvent        db.Event
 	labelsCategory *[]string
 	labelsType     *[]string
 }
 
+// WriteDB Record data in DB.
+func (core *coreIssues) WriteDB() {
+	core.dbEvent.InstallationID = core.ghc.GetInstallationID()
+	core.dbEvent.RepoOwner = core.ghc.GetRepoOwner()
+	core.dbEvent.RepoName = core.ghc.GetRepoName()
+	core.dbEvent.ID = core.ghc.GetIssueNumber()
+	core.dbEvent.LabelsCategory = *core.labelsCategory
+	core.dbEvent.LabelsType = *core.labelsType
+
+	x, err := json.Marshal(core.dbEvent)
+	if err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to marshal event")
+	} else {
+		err = core.eDB.Set([]byte(core.PathDB()), x)
+		if err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to set event in DB")
+		}
+	}
+}
+
+// ReadDB Read data from DB.
+func (core *coreIssues) ReadDB() {
+	x, err := core.eDB.Get([]byte(core.PathDB()))
+	if err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to get event in DB")
+	} else if x != nil {
+		if err = json.Unmarshal(x, &core.dbEvent); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to unmarshal event")
+		} else {
+			core.LoadLabels(core.dbEvent)
+		}
+	}
+}
+
+// Load labels from DB.
+func (core *coreIssues) LoadLabels(d db.Event) {
+	core.labelsCategory = &d.LabelsCategory
+	core.labelsType = &d.LabelsType
+}
+
+// PathDB return the path of the DB.
+func (core *coreIssues) PathDB() string {
+	return fmt.Sprintf("%d/%s/%s/%d", core.ghc.GetInstallationID(), core.ghc.GetRepoOwner(), core.ghc.GetRepoName(), core.ghc.GetIssueNumber())
+}
+
+// CheckTitle check if the title is valid
+// Check if the issue have a [SCOPE] title format and apply the scope label.
+func (core *coreIssues) CheckTitle() {
+	MsgIssuesTitleInvalid := comments.NewCommentMsg(core.ghc, comments.IDIssuesTitleInvalid, comments.IssuesTitleInvalidValues{
+		Title: core.ghc.GetIssue().GetTitle(),
+	})
+	if MsgIssuesTitleInvalid == nil {
+		core.ghc.Logger.Error().Msg("Failed to create comment")
+		return
+	}
+
+	// Remove the scope label of the previous title
+	if from := core.event.GetChanges().GetTitle().GetFrom(); from != "" {
+		if previousTitle, err := conventionalissue.ParseTitle(from); err == nil {
+			previousLabel := labeler.LabelScope(previousTitle.Scope()).GetLongName()
+			for i, l := range *core.labelsCategory {
+				if l == previousLabel {
+					*core.labelsCategory = append((*core.labelsCategory)[:i], (*core.labelsCategory)[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+
+	issueTitle, err := conventionalissue.ParseTitle(core.ghc.GetIssue().GetTitle())
+	if err != nil {
+		core.ghc.Logger.Debug().Msg("Error while parsing issue title")
+		if err := MsgIssuesTitleInvalid.EditIssueComment(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to edit issue comment")
+		}
+		return
+	}
+
+	// Remove issue comment if issue title is valid
+	if err := MsgIssuesTitleInvalid.RemoveIssueComment(); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to remove issue comment")
+	}
+
+	// Scope
+	label := labeler.LabelScope(issueTitle.Scope())
+	MsgIssuesLabelNotExists := comments.NewCommentMsg(core.ghc, comments.IDIssuesLabelNotExists, comments.IssuesLabelNotExistsValues{
+		Label: label.GetLongName(),
+	})
+	if MsgIssuesLabelNotExists == nil {
+		core.ghc.Logger.Error().Msg("Failed to create comment")
+		return
+	}
+
+	if _, err = core.ghc.GetLabel(label.GetLongName()); err != nil {
+		if err := MsgIssuesLabelNotExists.EditIssueComment(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to edit issue comment")
+		}
+	} else {
+		if err := MsgIssuesLabelNotExists.RemoveIssueComment(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to remove issue comment")
+		}
+		if _, ok := common.Find(*core.labelsCategory, label.GetLongName()); !ok {
+			*core.labelsCategory = append(*core.labelsCategory, label.GetLongName())
+		}
+	}
+}
+
+// CheckTemplate check if the body is valid
+// Check if the required fields of the issue form used to open the issue are filled and apply the labels of the form.
+func (core *coreIssues) CheckTemplate() {
+	forms, err := issueform.Load(core.ghc)
+	if err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to load issue forms")
+		return
+	}
+
+	form := issueform.Detect(forms, core.ghc.GetIssue().GetBody())
+	if form == nil {
+		core.ghc.Logger.Debug().Msg("Issue was not opened with an issue form")
+		return
+	}
+
+	core.ghc.Logger.Debug().Msgf("Issue was opened with the issue form %s", form.File)
+
+	// Labels of the issue form
+	labels := make([]string, 0)
+	for _, lbl := range form.Labels {
+		found := false
+		for _, l := range core.event.GetIssue().Labels {
+			if l.GetName() == lbl {
+				found = true
+				break
+			}
+		}
+		if !found {
+			labels = append(labels, lbl)
+		}
+	}
+	if len(labels) > 0 {
+		if err := core.ghc.AddLabelsToIssue(labels); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to add labels")
+		}
+	}
+
+	// Required fields
+	missing := form.MissingFields(core.ghc.GetIssue().GetBody())
+	MsgIssuesTemplateFieldsMissing := comments.NewCommentMsg(core.ghc, comments.IDIssuesTemplateFieldsMissing, comments.IssuesTemplateFieldsMissingValues{
+		Template: form.Name,
+		Fields:   missing,
+	})
+	if MsgIssuesTemplateFieldsMissing == nil {
+		core.ghc.Logger.Error().Msg("Failed to create comment")
+		return
+	}
+
+	if len(missing) > 0 {
+		if err := MsgIssuesTemplateFieldsMissing.EditIssueComment(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to edit issue comment")
+		}
+	} else {
+		if err := MsgIssuesTemplateFieldsMissing.RemoveIssueComment(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to remove issue comment")
+		}
+	}
+}
+
 // Community labels.
 func (core *coreIssues) Community() {
 	if *core.event.Issue.AuthorAssociation == "NONE" || *core.event.Issue.AuthorAssociation == "CONTRIBUTOR" {
@@ -101,6 +295,7 @@ func (core *coreIssues) Community() {
 }
 
 // ComputeLabels compute labels to add to the issue.
+// Only the category labels are removed, labels set by the users or by the issue templates are kept.
 func (core *coreIssues) ComputeLabels() error {
 	o := make([]string, 0)
 	allLabels := make([]string, 0)
@@ -109,7 +304,7 @@ func (core *coreIssues) ComputeLabels() error {
 
 	for _, lbl := range core.event.Issue.Labels {
 		core.ghc.Logger.Debug().Msgf("Label is %s", lbl.GetName())
-		if _, ok := common.Find(allLabels, lbl.GetName()); !ok {
+		if _, ok := common.Find(allLabels, lbl.GetName()); !ok && labeler.IsLabelScope(lbl.GetName()) && !core.dbEvent.IsLabelAddedByUser(lbl.GetName()) {
 			if err := core.ghc.RemoveLabelForIssue(lbl.GetName()); err != nil {
 				core.ghc.Logger.Error().Err(err).Msg("Failed to remove label")
 			}
@@ -118,7 +313,7 @@ func (core *coreIssues) ComputeLabels() error {
 	}
 
 	for _, lbl := range allLabels {
-		if _, ok := common.Find(o, lbl); !ok {
+		if _, ok := common.Find(o, lbl); !ok && !core.dbEvent.IsLabelRemovedByUser(lbl) {
 			if err := core.ghc.AddLabelToIssue(lbl); err != nil {
 				core.ghc.Logger.Error().Err(err).Msg("Failed to add label")
 			}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/FrangipaneTeam/crown/pkg/ghclient"
+	"github.com/FrangipaneTeam/crown/pkg/owners"
+	"github.com/FrangipaneTeam/crown/pkg/slashcommand"
+)
+
+// isAllowed returns true if the user has the permission.
+// The conditions are checked from the cheapest to the most expensive.
+func (core *coreIssueComment) isAllowed(login string, p slashcommand.Permission) (bool, error) {
+	if p.IsPublic() {
+		return true, nil
+	}
+
+	// Author of the issue or the pull request
+	if p.Author && strings.EqualFold(core.event.GetIssue().GetUser().GetLogin(), login) {
+		return true, nil
+	}
+
+	// Permission level on the repository
+	if p.HasLevel() {
+		level, err := core.permissionLevel(login)
+		if err != nil {
+			// The user may still be allowed by a team or the code owners
+			core.ghc.Logger.Error().Err(err).Str("user", login).Msg("Failed to get permission level")
+		} else if level >= p.Level {
+			return true, nil
+		}
+	}
+
+	// Teams
+	for _, team := range p.Teams {
+		if ok, err := isTeamMember(core.ghc, team, login); err != nil {
+			core.ghc.Logger.Error().Err(err).Str("team", team).Msg("Failed to get team membership")
+		} else if ok {
+			return true, nil
+		}
+	}
+
+	// Code owners of the changed files
+	if p.CodeOwners && core.event.GetIssue().IsPullRequest() {
+		return core.isCodeOwner(login)
+	}
+
+	return false, nil
+}
+
+// permissionLevel returns the permission level of the user on the repository.
+func (core *coreIssueComment) permissionLevel(login string) (slashcommand.Level, error) {
+	if level, ok := core.levels[login]; ok {
+		return level, nil
+	}
+
+	permission, err := core.ghc.GetPermissionLevel(login)
+	if err != nil {
+		return slashcommand.LevelNone, err
+	}
+
+	level, err := slashcommand.ParseLevel(permission)
+	if err != nil {
+		// Custom repository roles inherit at least the read permission
+		core.ghc.Logger.Debug().Err(err).Msg("Unknown permission level")
+		level = slashcommand.LevelRead
+	}
+
+	core.levels[login] = level
+	return level, nil
+}
+
+// isTeamMember returns true if the user is a member of the team (ex: org/team or team).
+func isTeamMember(ghc *ghclient.GHClient, team, login string) (bool, error) {
+	org, slug := ghc.GetOrg(), strings.TrimPrefix(team, "@")
+	if i := strings.Index(slug, "/"); i >= 0 {
+		org, slug = slug[:i], slug[i+1:]
+	}
+
+	return ghc.IsTeamMember(org, slug, login)
+}
+
+// isCodeOwner returns true if the user owns all the files changed by the pull request.
+func (core *coreIssueComment) isCodeOwner(login string) (bool, error) {
+	co, err := owners.LoadCodeOwners(core.ghc)
+	if err != nil {
+		if errors.Is(err, owners.ErrCodeOwnersNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	files, err := core.ghc.ListFiles()
+	if err != nil {
+		return false, err
+	}
+
+	for _, f := range files {
+		if !isOwner(core.ghc, co.Owners(f), login) {
+			return false, nil
+		}
+	}
+
+	return len(files) > 0, nil
+}
+
+// isOwner returns true if the user is one of the owners (@user or @org/team).
+func isOwner(ghc *ghclient.GHClient, list []string, login string) bool {
+	for _, o := range list {
+		if !strings.HasPrefix(o, "@") {
+			// Emails are not resolved
+			continue
+		}
+		if strings.Contains(o, "/") {
+			if ok, err := isTeamMember(ghc, o, login); err != nil {
+				ghc.Logger.Error().Err(err).Str("team", o).Msg("Failed to get team membership")
+			} else if ok {
+				return true
+			}
+			continue
+		}
+		if strings.EqualFold(strings.TrimPrefix(o, "@"), login) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyPermissionsConfig overrides the default permissions of the commands with the configuration.
+func (core *coreIssueComment) applyPermissionsConfig(r *slashcommand.Registry) {
+	for name, c := range core.config.SlashCommands.Permissions {
+		p, err := slashcommand.PermissionFromConfig(c)
+		if err != nil {
+			core.ghc.Logger.Error().Err(err).Str("command", name).Msg("Invalid permission in configuration")
+			continue
+		}
+		if !r.SetPermission(name, p) {
+			core.ghc.Logger.Warn().Msgf("Unknown command %s in configuration", name)
+		}
+	}
+}
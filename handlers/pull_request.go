@@ -4,20 +4,27 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/azrod/common-go"
 	"github.com/google/go-github/v47/github"
 	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/pkg/errors"
+	"github.com/shurcooL/githubv4"
 
 	"github.com/FrangipaneTeam/crown/handlers/comments"
 	"github.com/FrangipaneTeam/crown/handlers/status"
+	"github.com/FrangipaneTeam/crown/pkg/config"
+	"github.com/FrangipaneTeam/crown/pkg/conventionalbranch"
 	"github.com/FrangipaneTeam/crown/pkg/conventionalcommit"
 	"github.com/FrangipaneTeam/crown/pkg/conventionalsizepr"
 	"github.com/FrangipaneTeam/crown/pkg/db"
+	"github.com/FrangipaneTeam/crown/pkg/dco"
 	"github.com/FrangipaneTeam/crown/pkg/ghclient"
+	"github.com/FrangipaneTeam/crown/pkg/issueref"
 	"github.com/FrangipaneTeam/crown/pkg/labeler"
+	"github.com/FrangipaneTeam/crown/pkg/prtemplate"
 	"github.com/FrangipaneTeam/crown/pkg/statustype"
 )
 
@@ -26,6 +33,7 @@ import (
 
 type PullRequestHandler struct {
 	githubapp.ClientCreator
+	Config *config.CrownConfig
 }
 
 // Handles returns the list of events this handler handles..
@@ -73,15 +81,7 @@ func (h *PullRequestHandler) Handle(ctx context.Context, _, _ string, payload []
 
 	ghc.Logger.Debug().Msgf("Event action is %s in Handle PullRequest", event.GetAction())
 
-	core := &corePR{
-		ghc:            ghc,
-		eDB:            db.EventDBNew(db.DBEvent),
-		event:          event,
-		labelsCategory: &[]string{},
-		labelsType:     &[]string{},
-	}
-
-	core.commitSHA = event.GetPullRequest().GetHead().GetSHA()
+	core := newCorePR(ghc, h.Config, event)
 	if core.commitSHA == "" {
 		ghc.Logger.Error().Msg("Failed to get commit SHA")
 		return nil
@@ -89,27 +89,24 @@ func (h *PullRequestHandler) Handle(ctx context.Context, _, _ string, payload []
 
 	// Generic Action
 	switch event.GetAction() {
-	case "opened", "edited", "synchronize":
-
-		// ? Init status
-		// At this instant, All status are pending
-		core.PR_Check_Title = status.NewStatus(ghc, status.PR_Check_Title, core.commitSHA)
-		core.PR_Check_commits = status.NewStatus(ghc, status.PR_Check_Commits, core.commitSHA)
-		core.PR_Labeler = status.NewStatus(ghc, status.PR_Labeler, core.commitSHA)
-		core.PR_Check_SizeChanges = status.NewStatus(ghc, status.PR_Check_SizeChanges, core.commitSHA)
+	case "opened", "edited", "synchronize", "reopened", "ready_for_review", "converted_to_draft":
+		core.Run()
 
-		// Check if title respect conventional commit
-		core.CheckTitle()
-		// Check if commits respect conventional commit
-		core.CheckCommits()
-		// Check if PR respect size
-		core.CheckSizePR()
-		// Check if author is COMMUNITY
-		// core.Community()
+	case "closed":
+		core.Close()
 
-		core.WriteDB()
-
-		core.ComputeLabels()
+	case "labeled", "unlabeled":
+		// Labels set by crown are ignored, the choices of the users and the other apps are recorded
+		isCrown, err := ghc.IsApp(event.GetSender().GetLogin())
+		if err != nil {
+			ghc.Logger.Error().Err(err).Msg("Failed to get the login of the app")
+			// Without the login of the app, all the bots are ignored
+			isCrown = event.GetSender().GetType() == "Bot"
+		}
+		if isCrown {
+			return nil
+		}
+		core.RecordUserLabel(event.GetAction(), event.GetLabel().GetName())
 
 	default:
 		return nil
@@ -121,8 +118,11 @@ func (h *PullRequestHandler) Handle(ctx context.Context, _, _ string, payload []
 type corePR struct {
 	ghc            *ghclient.GHClient
 	eDB            *db.EventDB
+	config         *config.CrownConfig
 	event          github.PullRequestEvent
 	commitSHA      string
+	commits        []*github.RepositoryCommit
+	dbEvent        db.Event
 	labelsCategory *[]string
 	labelsType     *[]string
 
@@ -130,17 +130,339 @@ type corePR struct {
 	PR_Check_commits     *status.Status //nolint:revive,stylecheck
 	PR_Check_SizeChanges *status.Status //nolint:revive,stylecheck
 	PR_Labeler           *status.Status //nolint:revive,stylecheck
+	PR_Check_Description *status.Status //nolint:revive,stylecheck
+	PR_Check_LinkedIssue *status.Status //nolint:revive,stylecheck
+	PR_Check_DCO         *status.Status //nolint:revive,stylecheck
+	PR_Check_Signature   *status.Status //nolint:revive,stylecheck
+	PR_Check_Branch      *status.Status //nolint:revive,stylecheck
 }
 
-// WriteDB Record data in DB..
-func (core *corePR) WriteDB() {
-	x, err := json.Marshal(db.Event{
+// newCorePR returns the core of the pull request event.
+func newCorePR(ghc *ghclient.GHClient, cfg *config.CrownConfig, event github.PullRequestEvent) *corePR {
+	return &corePR{
+		ghc:            ghc,
+		eDB:            db.EventDBNew(db.DBEvent),
+		config:         cfg,
+		event:          event,
+		commitSHA:      event.GetPullRequest().GetHead().GetSHA(),
+		labelsCategory: &[]string{},
+		labelsType:     &[]string{},
+	}
+}
+
+// Run runs all the checks of the pull request.
+func (core *corePR) Run() {
+	// ? Init status
+	// At this instant, All status are pending
+	core.InitStatuses()
+
+	// Read the previous record (ex: DCO override, hold)
+	core.ReadDB()
+
+	// A reopened PR keeps its record, it is no longer purged
+	reopened := core.dbEvent.IsArchived() && core.event.GetPullRequest().GetState() == "open"
+	if reopened {
+		core.dbEvent.Activate()
+	}
+
+	// Check if title is prefixed with WIP
+	if core.config.WIP.Enabled {
+		core.CheckWIP()
+	}
+	// The hold survives the new commits
+	core.CheckHold()
+
+	// Draft PR are checked when they are ready for review
+	if core.event.GetPullRequest().GetDraft() {
+		core.Draft()
+		if reopened {
+			core.LoadLabels(core.dbEvent)
+			core.WriteDB()
+		}
+		return
+	}
+
+	// Check if title respect conventional commit
+	core.CheckTitle()
+	// Check if commits respect conventional commit
+	core.CheckCommits()
+	// Check if commits have a verified signature
+	if core.config.Signature.Enabled {
+		core.CheckSignature()
+	}
+	// Check if branch name respect the naming policy
+	if core.config.BranchNaming.Enabled {
+		core.CheckBranch()
+	}
+	// Check if PR respect size
+	core.CheckSizePR()
+	// Check if PR description respect the template
+	core.CheckDescription()
+	// Check if PR is linked to an open issue
+	if core.config.LinkedIssue.IsEnabled() {
+		core.CheckLinkedIssue()
+	}
+	// New commits reset the lgtm, the approval is kept if the approver owns the new changes
+	if core.config.Approval.Enabled {
+		// A recheck is not a push
+		if core.event.GetAction() != ActionRecheck {
+			core.ResetLGTM()
+		}
+		core.CheckApproval()
+	}
+	// Check if author is COMMUNITY
+	// core.Community()
+
+	core.WriteDB()
+
+	core.ComputeLabels()
+}
+
+// ActionRecheck is the action of the pull request event rebuilt by /recheck.
+const ActionRecheck = "recheck"
+
+// RecheckSteps are the steps which can be rechecked separately.
+var RecheckSteps = []string{"title", "commits", "size", "labels"}
+
+// Recheck runs the selected steps, all the checks are run if steps is empty.
+// The labels are computed from the title, the commits and the size, so the labels step runs them all.
+func (core *corePR) Recheck(steps []string) {
+	if len(steps) == 0 {
+		core.Run()
+		return
+	}
+
+	core.ReadDB()
+
+	if _, ok := common.Find(steps, "labels"); ok {
+		core.PR_Check_Title = status.NewStatus(core.ghc, status.PR_Check_Title, core.commitSHA)
+		core.PR_Check_commits = status.NewStatus(core.ghc, status.PR_Check_Commits, core.commitSHA)
+		core.PR_Check_SizeChanges = status.NewStatus(core.ghc, status.PR_Check_SizeChanges, core.commitSHA)
+		core.PR_Labeler = status.NewStatus(core.ghc, status.PR_Labeler, core.commitSHA)
+
+		core.CheckTitle()
+		core.CheckCommits()
+		core.CheckSizePR()
+
+		core.WriteDB()
+		core.ComputeLabels()
+		return
+	}
+
+	for _, step := range steps {
+		switch step {
+		case "title":
+			core.PR_Check_Title = status.NewStatus(core.ghc, status.PR_Check_Title, core.commitSHA)
+			core.PR_Labeler = status.NewStatus(core.ghc, status.PR_Labeler, core.commitSHA)
+			core.CheckTitle()
+			// The labels are not recomputed, the labeler is only affected by the scope of the title
+			if err := core.PR_Labeler.IsSuccess(); err != nil {
+				core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+			}
+		case "commits":
+			core.PR_Check_commits = status.NewStatus(core.ghc, status.PR_Check_Commits, core.commitSHA)
+			if core.config.DCO.Enabled {
+				core.PR_Check_DCO = status.NewStatus(core.ghc, status.PR_Check_DCO, core.commitSHA)
+			}
+			core.CheckCommits()
+			if core.config.Signature.Enabled {
+				core.PR_Check_Signature = status.NewStatus(core.ghc, status.PR_Check_Signature, core.commitSHA)
+				core.CheckSignature()
+			}
+		case "size":
+			core.PR_Check_SizeChanges = status.NewStatus(core.ghc, status.PR_Check_SizeChanges, core.commitSHA)
+			core.CheckSizePR()
+		}
+	}
+}
+
+// RecordUserLabel records a label added or removed by a human.
+// The next ComputeLabels respects this choice.
+func (core *corePR) RecordUserLabel(action, label string) {
+	core.ReadDB()
+	core.LoadLabels(core.dbEvent)
+
+	switch action {
+	case "labeled":
+		core.dbEvent.UserLabeled(label)
+	case "unlabeled":
+		core.dbEvent.UserUnlabeled(label)
+	}
+
+	core.WriteDB()
+}
+
+// Close cleans up the PR when it's closed.
+// The bot comments are minimized. Once merged, the PR is recorded and the Event record is deleted,
+// a PR closed without merge keeps its record (hold, overrides, labels set by users...) for a reopen
+// until it is purged with the verdicts of its commits once the retention is over.
+func (core *corePR) Close() {
+	if core.event.GetPullRequest().GetMerged() {
+		core.RecordMerged()
+
+		// The cherry-picks requested before the merge are run once the record is cleaned up
+		core.ReadDB()
+		defer core.CherryPicks(core.dbEvent.CherryPicks)
+
+		if err := core.eDB.Delete([]byte(core.PathDB())); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to delete event in DB")
+		}
+		if err := db.CommitDBNew().DeleteVerdicts(core.PathDB()); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to delete commit verdicts in DB")
+		}
+	} else {
+		core.ReadDB()
+		core.LoadLabels(core.dbEvent)
+		core.dbEvent.Archive()
+		core.WriteDB()
+	}
+
+	cts, err := comments.ListBotComments(core.ghc)
+	if err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to list bot comments")
+		return
+	}
+
+	for _, c := range cts {
+		// The results of the cherry-picks stay visible
+		if ok, value := comments.ExtraIssueComment(c.GetBody(), comments.IDPRCherryPick, comments.ExtraBotID); ok && comments.IDPRCherryPick.IsValid(value) {
+			continue
+		}
+		if err := core.ghc.MinimizeComment(c.GetNodeID(), githubv4.ReportedContentClassifiersOutdated); err != nil {
+			core.ghc.Logger.Error().Err(err).Int64("commentID", c.GetID()).Msg("Failed to minimize comment")
+		}
+	}
+}
+
+// RecordMerged records the final type, scope and size of the merged PR.
+func (core *corePR) RecordMerged() {
+	pr := core.event.GetPullRequest()
+	m := db.Merged{
 		InstallationID: core.ghc.GetInstallationID(),
 		RepoOwner:      core.ghc.GetRepoOwner(),
 		RepoName:       core.ghc.GetRepoName(),
-		LabelsCategory: *core.labelsCategory,
-		LabelsType:     *core.labelsType,
-	})
+		ID:             pr.GetNumber(),
+		Title:          pr.GetTitle(),
+		Size:           conventionalsizepr.NewPRSize(pr.GetAdditions(), pr.GetDeletions()).GetSize().GetSize(),
+		Author:         pr.GetUser().GetLogin(),
+		MergedAt:       pr.GetMergedAt(),
+	}
+
+	if title, err := conventionalcommit.ParseCommit(core.Title()); err == nil {
+		m.Type = title.Type()
+		m.Scope = title.Scope()
+		m.BreakingChange = title.IsBreakingChange()
+	}
+
+	if err := db.MergedDBNew().AddMerged(m); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to record merged PR")
+	}
+}
+
+// InitStatuses creates the statuses of the enabled checks.
+func (core *corePR) InitStatuses() {
+	core.PR_Check_Title = status.NewStatus(core.ghc, status.PR_Check_Title, core.commitSHA)
+	core.PR_Check_commits = status.NewStatus(core.ghc, status.PR_Check_Commits, core.commitSHA)
+	core.PR_Labeler = status.NewStatus(core.ghc, status.PR_Labeler, core.commitSHA)
+	core.PR_Check_SizeChanges = status.NewStatus(core.ghc, status.PR_Check_SizeChanges, core.commitSHA)
+	core.PR_Check_Description = status.NewStatus(core.ghc, status.PR_Check_Description, core.commitSHA)
+	if core.config.LinkedIssue.IsEnabled() {
+		core.PR_Check_LinkedIssue = status.NewStatus(core.ghc, status.PR_Check_LinkedIssue, core.commitSHA)
+	}
+	if core.config.DCO.Enabled {
+		core.PR_Check_DCO = status.NewStatus(core.ghc, status.PR_Check_DCO, core.commitSHA)
+	}
+	if core.config.Signature.Enabled {
+		core.PR_Check_Signature = status.NewStatus(core.ghc, status.PR_Check_Signature, core.commitSHA)
+	}
+	if core.config.BranchNaming.Enabled {
+		core.PR_Check_Branch = status.NewStatus(core.ghc, status.PR_Check_Branch, core.commitSHA)
+	}
+}
+
+// Statuses returns the statuses of the enabled checks.
+func (core *corePR) Statuses() []*status.Status {
+	x := make([]*status.Status, 0)
+	for _, s := range []*status.Status{
+		core.PR_Check_Title,
+		core.PR_Check_commits,
+		core.PR_Labeler,
+		core.PR_Check_SizeChanges,
+		core.PR_Check_Description,
+		core.PR_Check_LinkedIssue,
+		core.PR_Check_DCO,
+		core.PR_Check_Signature,
+		core.PR_Check_Branch,
+	} {
+		if s != nil {
+			x = append(x, s)
+		}
+	}
+	return x
+}
+
+// Draft keeps the statuses pending while the PR is a draft.
+// No comment is posted on a draft PR.
+func (core *corePR) Draft() {
+	for _, s := range core.Statuses() {
+		if err := s.SetStateWithDescription(statustype.Pending, "Waiting for the PR to be ready for review"); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+		}
+	}
+}
+
+// Title returns the title of the PR without the WIP prefix.
+func (core *corePR) Title() string {
+	if core.config.WIP.Enabled {
+		return conventionalcommit.TrimWIP(core.ghc.GetPullRequest().GetTitle())
+	}
+	return core.ghc.GetPullRequest().GetTitle()
+}
+
+// CheckWIP check if the title is prefixed with WIP.
+func (core *corePR) CheckWIP() {
+	s := status.NewStatus(core.ghc, status.PR_WIP, core.commitSHA)
+	if conventionalcommit.IsWIP(core.ghc.GetPullRequest().GetTitle()) {
+		if err := s.SetState(statustype.Failure); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+		}
+		return
+	}
+
+	if err := s.IsSuccess(); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+	}
+}
+
+// CheckHold sets the hold status from the record of the pull request.
+func (core *corePR) CheckHold() {
+	if err := setHoldStatus(core.ghc, core.commitSHA, core.dbEvent); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+	}
+}
+
+// Commits returns the commits of the pull request.
+// The commits are fetched once per event.
+func (core *corePR) Commits() ([]*github.RepositoryCommit, error) {
+	if core.commits == nil {
+		commits, err := core.ghc.GetCommits()
+		if err != nil {
+			return nil, err
+		}
+		core.commits = commits
+	}
+	return core.commits, nil
+}
+
+// WriteDB Record data in DB..
+func (core *corePR) WriteDB() {
+	core.dbEvent.InstallationID = core.ghc.GetInstallationID()
+	core.dbEvent.RepoOwner = core.ghc.GetRepoOwner()
+	core.dbEvent.RepoName = core.ghc.GetRepoName()
+	core.dbEvent.LabelsCategory = *core.labelsCategory
+	core.dbEvent.LabelsType = *core.labelsType
+
+	x, err := json.Marshal(core.dbEvent)
 	if err != nil {
 		core.ghc.Logger.Error().Err(err).Msg("Failed to marshal event")
 	} else {
@@ -152,16 +474,14 @@ func (core *corePR) WriteDB() {
 }
 
 // ReadDB Read data from DB.
+// The labels are not loaded, they are computed on each event.
 func (core *corePR) ReadDB() {
 	x, err := core.eDB.Get([]byte(core.PathDB()))
 	if err != nil {
 		core.ghc.Logger.Error().Err(err).Msg("Failed to get event in DB")
-	} else {
-		dbEvent := db.Event{}
-		if err = json.Unmarshal(x, &dbEvent); err != nil {
+	} else if x != nil {
+		if err = json.Unmarshal(x, &core.dbEvent); err != nil {
 			core.ghc.Logger.Error().Err(err).Msg("Failed to unmarshal event")
-		} else {
-			core.LoadLabels(dbEvent)
 		}
 	}
 }
@@ -195,7 +515,7 @@ func (core *corePR) CheckTitle() {
 	if MsgPRTitleInvalid == nil {
 		core.ghc.Logger.Error().Msg("Failed to create comment")
 	}
-	PrTitle, err := conventionalcommit.ParseCommit(core.ghc.GetPullRequest().GetTitle())
+	PrTitle, err := conventionalcommit.ParseCommit(core.Title())
 	if err != nil {
 		core.ghc.Logger.Debug().Msg("Error while parsing PR title")
 		if err := core.PR_Check_Title.SetState(statustype.Failure); err != nil {
@@ -293,146 +613,359 @@ func (core *corePR) CheckTitle() {
 	}
 }
 
+// CheckDCO check if the commits are signed off by their author.
+// Merge commits are ignored. An override is only valid for the head it was given on.
+func (core *corePR) CheckDCO(commits []*github.RepositoryCommit) {
+	if core.dbEvent.DCOOverrideBy != "" && core.dbEvent.DCOOverrideHeadSHA != core.commitSHA {
+		core.dbEvent.DCOOverrideBy = ""
+		core.dbEvent.DCOOverrideHeadSHA = ""
+	}
+
+	if core.dbEvent.DCOOverrideBy != "" {
+		if err := core.PR_Check_DCO.SetStateWithDescription(statustype.Success, fmt.Sprintf("DCO check overridden by @%s", core.dbEvent.DCOOverrideBy)); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+		}
+		return
+	}
+
+	unsigned := make([]string, 0)
+	for _, commit := range commits {
+		if dco.IsMergeCommit(commit) {
+			continue
+		}
+
+		// cm is nil if the commit message is not conventional, the sign-off is then searched in the message
+		cm, _ := conventionalcommit.ParseCommit(commit.GetCommit().GetMessage())
+		if !dco.IsSigned(commit, cm) {
+			author := commit.GetCommit().GetAuthor()
+			unsigned = append(unsigned, fmt.Sprintf("%s %s <%s>", commit.GetSHA(), author.GetName(), author.GetEmail()))
+		}
+	}
+
+	MsgPRDCOInvalid := comments.NewCommentMsg(core.ghc, comments.IDPRDCOInvalid, comments.PRDCOInvalidValues{
+		Commits:         unsigned,
+		NumberOfCommits: len(commits),
+	})
+	if MsgPRDCOInvalid == nil {
+		core.ghc.Logger.Error().Msg("Failed to create comment")
+		return
+	}
+
+	if len(unsigned) > 0 {
+		if err := core.PR_Check_DCO.SetState(statustype.Failure); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+		}
+		if err := MsgPRDCOInvalid.EditIssueComment(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to edit issue comment")
+		}
+		return
+	}
+
+	if err := MsgPRDCOInvalid.RemoveIssueComment(); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to remove issue comment")
+	}
+	if err := core.PR_Check_DCO.IsSuccess(); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+	}
+}
+
+// CheckBranch check if the head branch name respect the naming policy
+// and if the branch type matches the PR title type.
+func (core *corePR) CheckBranch() {
+	branch := core.ghc.GetPullRequest().GetHead().GetRef()
+	if conventionalbranch.IsExempt(branch, core.config.BranchNaming.Exempt) {
+		if err := core.PR_Check_Branch.SetStateWithDescription(statustype.Success, "Branch name is exempt"); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+		}
+		return
+	}
+
+	problems := make([]string, 0)
+	b, err := conventionalbranch.ParseBranch(branch)
+	if err != nil {
+		problems = append(problems, err.Error())
+	} else if title, err := conventionalcommit.ParseCommit(core.Title()); err == nil {
+		// The title is reported by CheckTitle if it's invalid
+		if t, ok := labeler.FindLabelerType(title); ok && t != b.Type() {
+			problems = append(problems, fmt.Sprintf("branch type `%s` does not match the PR title type `%s`", b.Type().GetShortName(), t.GetShortName()))
+		}
+	}
+
+	MsgPRBranchInvalid := comments.NewCommentMsg(core.ghc, comments.IDPRBranchInvalid, comments.PRBranchInvalidValues{
+		Branch:   branch,
+		Problems: problems,
+		Patterns: conventionalbranch.Patterns,
+		Types:    conventionalbranch.Types(),
+	})
+	if MsgPRBranchInvalid == nil {
+		core.ghc.Logger.Error().Msg("Failed to create comment")
+		return
+	}
+
+	if len(problems) > 0 {
+		if err := core.PR_Check_Branch.SetState(statustype.Failure); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+		}
+		if err := MsgPRBranchInvalid.EditIssueComment(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to edit issue comment")
+		}
+		return
+	}
+
+	if err := MsgPRBranchInvalid.RemoveIssueComment(); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to remove issue comment")
+	}
+	if err := core.PR_Check_Branch.IsSuccess(); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+	}
+}
+
+// CheckSignature check if the commits have a verified signature.
+// The commits of the allowed bots are ignored.
+func (core *corePR) CheckSignature() {
+	commits, err := core.Commits()
+	if err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to get commits")
+		if err := core.PR_Check_Signature.SetState(statustype.Failure); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+		}
+		return
+	}
+
+	unverified := make([]string, 0)
+	for _, commit := range commits {
+		if core.config.Signature.IsAllowedBot(commit.GetAuthor().GetLogin()) {
+			continue
+		}
+
+		verification := commit.GetCommit().GetVerification()
+		if !verification.GetVerified() {
+			unverified = append(unverified, fmt.Sprintf("%s : `%s`", commit.GetSHA(), verification.GetReason()))
+		}
+	}
+
+	MsgPRSignatureInvalid := comments.NewCommentMsg(core.ghc, comments.IDPRSignatureInvalid, comments.PRSignatureInvalidValues{
+		Commits: unverified,
+	})
+	if MsgPRSignatureInvalid == nil {
+		core.ghc.Logger.Error().Msg("Failed to create comment")
+		return
+	}
+
+	if len(unverified) > 0 {
+		if err := core.PR_Check_Signature.SetState(statustype.Failure); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+		}
+		if err := MsgPRSignatureInvalid.EditIssueComment(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to edit issue comment")
+		}
+		return
+	}
+
+	if err := MsgPRSignatureInvalid.RemoveIssueComment(); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to remove issue comment")
+	}
+	if err := core.PR_Check_Signature.IsSuccess(); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+	}
+}
+
 // CheckCommits check if the commits are valid
 // Check if the PullRequest have a conventional commit format.
-func (core *corePR) CheckCommits() { //nolint:gocyclo
+// The verdicts are cached by commit SHA, only the new commits are linted.
+func (core *corePR) CheckCommits() {
 	// ? ParseCommits
-	commits, err := core.ghc.GetCommits()
+	commits, err := core.Commits()
 	if err != nil {
 		core.ghc.Logger.Error().Err(err).Msg("Failed to get commits")
 		if err := core.PR_Check_commits.SetState(statustype.Failure); err != nil {
 			core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
 		}
-	} else {
-		allCommitsSHA := make([]string, 0)
+		return
+	}
 
-		for _, commit := range commits {
-			MsgPRCommitInvalid := comments.NewCommentMsg(core.ghc, comments.IDPRCommitInvalid, comments.PRCommitInvalidValues{
-				CommitMsg: commit.GetCommit().GetMessage(),
-				CommitSHA: commit.GetSHA(),
-			})
-			if MsgPRCommitInvalid == nil {
-				core.ghc.Logger.Error().Msg("Failed to create comment")
-				continue
-			}
+	// Check if commits are signed off
+	if core.PR_Check_DCO != nil {
+		core.CheckDCO(commits)
+	}
 
-			allCommitsSHA = append(allCommitsSHA, commit.GetSHA())
+	var (
+		allCommitsSHA = make([]string, 0)
+		cDB           = db.CommitDBNew()
+		lintVersion   = commitLintVersion
+	)
 
-			core.ghc.Logger.Debug().Msgf("Commit message is %s", commit.GetCommit().GetMessage())
+	for _, commit := range commits {
+		allCommitsSHA = append(allCommitsSHA, commit.GetSHA())
 
-			cm, err := conventionalcommit.ParseCommit(commit.GetCommit().GetMessage())
-			if err != nil {
-				core.ghc.Logger.Error().Str("message", commit.GetCommit().GetMessage()).Str("commitID", commit.GetSHA()).Msg("Commit message is not conventional commit format")
-				if err := core.PR_Check_commits.SetState(statustype.Failure); err != nil {
-					core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
-				}
-				if err := MsgPRCommitInvalid.EditIssueComment(); err != nil {
-					core.ghc.Logger.Error().Err(err).Msg("Failed to edit issue comment")
-					continue
-				}
-			} else {
-				// Remove issue comment if commit message is valid
-				if err := MsgPRCommitInvalid.RemoveIssueComment(); err != nil {
-					core.ghc.Logger.Error().Err(err).Msg("Failed to remove issue comment")
-					continue
-				}
+		// Reuse the verdict of a commit already linted with the same version of the lint
+		if v, err := cDB.GetVerdict(core.PathDB(), commit.GetSHA()); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to get commit verdict in DB")
+		} else if v != nil && v.ConfigHash == lintVersion {
+			core.ghc.Logger.Debug().Str("commitID", commit.GetSHA()).Msg("Commit already linted")
+			core.applyCommitVerdict(commit, v, true)
+			continue
+		}
 
-				// Scope
-				if cm.Scope() != "" {
-					MsgPRIssuesLabelNotExists := comments.NewCommentMsg(core.ghc, comments.IDIssuesLabelNotExists, comments.IssuesLabelNotExistsValues{
-						Label: labeler.LabelScope(cm.Scope()).GetLongName(),
-					})
-					if MsgPRIssuesLabelNotExists == nil {
-						core.ghc.Logger.Error().Msg("Failed to create comment")
-						continue
-					}
-					_, err = core.ghc.GetLabel(labeler.LabelScope(cm.Scope()).GetLongName())
-					if err != nil {
-						if err := core.PR_Check_commits.SetState(statustype.Failure); err != nil {
-							if eer := MsgPRIssuesLabelNotExists.EditIssueComment(); err != nil {
-								core.ghc.Logger.Error().Err(eer).Msg("Failed to edit issue comment")
-								continue
-							}
-						} else {
-							if _, ok := common.Find(*core.labelsCategory, labeler.LabelScope(cm.Scope()).GetLongName()); !ok {
-								*core.labelsCategory = append(*core.labelsCategory, labeler.LabelScope(cm.Scope()).GetLongName())
-							}
-						}
-					}
-					// Type
-					if v, ok := labeler.FindLabelerType(cm); !ok {
-						core.ghc.Logger.Error().Str("message", commit.GetCommit().GetMessage()).Str("commitID", commit.GetSHA()).Msg("Commit message is not conventional commit format")
-						if err := core.PR_Check_commits.SetState(statustype.Failure); err != nil {
-							core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
-						}
-						if err := MsgPRCommitInvalid.EditIssueComment(); err != nil {
-							core.ghc.Logger.Error().Err(err).Msg("Failed to edit issue comment")
-							continue
-						}
-					} else {
-						_, err = core.ghc.GetLabel(v.GetLongName())
-						if err != nil {
-							err = core.ghc.CreateLabel(v.GitHubLabel())
-							if err != nil {
-								core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
-								core.ghc.Logger.Error().Err(err).Msg("Failed to create label")
-							} else {
-								if _, ok := common.Find(*core.labelsType, v.GetLongName()); !ok {
-									*core.labelsType = append(*core.labelsType, v.GetLongName())
-								}
-							}
-						} else {
-							if _, ok := common.Find(*core.labelsType, v.GetLongName()); !ok {
-								*core.labelsType = append(*core.labelsType, v.GetLongName())
-							}
-						}
-					}
+		v, cacheable := core.lintCommit(commit)
+		v.ConfigHash = lintVersion
+		core.applyCommitVerdict(commit, v, false)
+
+		if cacheable {
+			if err := cDB.SetVerdict(core.PathDB(), *v); err != nil {
+				core.ghc.Logger.Error().Err(err).Msg("Failed to set commit verdict in DB")
+			}
+		}
+	}
+
+	if err := core.PR_Check_commits.IsSuccess(); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+	}
 
-					// Breaking change
-					if cm.IsBreakingChange() {
-						_, err := core.ghc.GetLabel(labeler.BreakingChange.GetLongName())
-						if err != nil {
-							if err := core.ghc.CreateLabel(labeler.BreakingChange.GithubLabel()); err != nil {
-								core.ghc.Logger.Error().Err(err).Msg("Failed to create label")
-							}
-						} else {
-							if _, ok := common.Find(*core.labelsType, labeler.BreakingChange.GetLongName()); !ok {
-								*core.labelsType = append(*core.labelsType, labeler.BreakingChange.GetLongName())
-							}
-						}
-					} else {
-						if _, ok := common.Find(*core.labelsType, labeler.BreakingChange.GetLongName()); !ok {
-							*core.labelsType = append(*core.labelsType, labeler.BreakingChange.GetLongName())
-						}
+	cts, err := core.ghc.ListComments()
+	if err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to get comments")
+		return
+	}
+	for _, comment := range cts {
+		if ok, value := comments.ExtraIssueComment(comment.GetBody(), comments.IDPRCommitInvalid, comments.ExtraBotID); ok && comments.IDPRCommitInvalid.IsValid(value) {
+			if ok, value := comments.ExtraIssueComment(comment.GetBody(), comments.IDPRCommitInvalid, comments.ExtraCommitID); ok {
+				if _, ok := common.Find(allCommitsSHA, value); !ok {
+					// Delete commit for invalid commit message with commitID has been deleted
+					if err := core.ghc.DeleteComment(comment.GetID()); err != nil {
+						core.ghc.Logger.Error().Err(err).Msg("Failed to delete comment")
 					}
 				}
+			}
+		}
+	}
+}
 
-				if err := core.PR_Check_commits.IsSuccess(); err != nil {
-					core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+// commitLintVersion is the version of lintCommit recorded with the verdicts.
+// The verdict depends only on the commit message and on the labeler, not on the configuration,
+// so it must be increased when lintCommit or the labeler types change to lint the commits again.
+const commitLintVersion = "1"
+
+// lintCommit lints the commit message and returns its verdict.
+// The verdict is not cacheable if it depends on a transient state (API error, missing label).
+func (core *corePR) lintCommit(commit *github.RepositoryCommit) (verdict *db.CommitVerdict, cacheable bool) {
+	verdict = &db.CommitVerdict{
+		SHA:            commit.GetSHA(),
+		Valid:          true,
+		LabelsType:     make([]string, 0),
+		LabelsCategory: make([]string, 0),
+	}
+	cacheable = true
+
+	core.ghc.Logger.Debug().Msgf("Commit message is %s", commit.GetCommit().GetMessage())
+
+	cm, err := conventionalcommit.ParseCommit(commit.GetCommit().GetMessage())
+	if err != nil {
+		core.ghc.Logger.Error().Str("message", commit.GetCommit().GetMessage()).Str("commitID", commit.GetSHA()).Msg("Commit message is not conventional commit format")
+		verdict.Valid = false
+		return verdict, cacheable
+	}
+
+	// Type
+	v, ok := labeler.FindLabelerType(cm)
+	if !ok {
+		core.ghc.Logger.Error().Str("message", commit.GetCommit().GetMessage()).Str("commitID", commit.GetSHA()).Msg("Commit message is not conventional commit format")
+		verdict.Valid = false
+		return verdict, cacheable
+	}
+	if _, err := core.ghc.GetLabel(v.GetLongName()); err != nil {
+		if err := core.ghc.CreateLabel(v.GitHubLabel()); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to create label")
+			cacheable = false
+		} else {
+			verdict.LabelsType = append(verdict.LabelsType, v.GetLongName())
+		}
+	} else {
+		verdict.LabelsType = append(verdict.LabelsType, v.GetLongName())
+	}
+
+	// Scope
+	if cm.Scope() != "" {
+		MsgPRIssuesLabelNotExists := comments.NewCommentMsg(core.ghc, comments.IDIssuesLabelNotExists, comments.IssuesLabelNotExistsValues{
+			Label: labeler.LabelScope(cm.Scope()).GetLongName(),
+		})
+		if _, err := core.ghc.GetLabel(labeler.LabelScope(cm.Scope()).GetLongName()); err != nil {
+			// The label can be created later with a slash command
+			cacheable = false
+			if err := core.PR_Check_commits.SetState(statustype.Failure); err != nil {
+				core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+			}
+			if MsgPRIssuesLabelNotExists != nil {
+				if err := MsgPRIssuesLabelNotExists.EditIssueComment(); err != nil {
+					core.ghc.Logger.Error().Err(err).Msg("Failed to edit issue comment")
 				}
 			}
+		} else {
+			verdict.LabelsCategory = append(verdict.LabelsCategory, labeler.LabelScope(cm.Scope()).GetLongName())
 		}
+	}
 
-		cts, err := core.ghc.ListComments()
-		if err != nil {
-			core.ghc.Logger.Error().Err(err).Msg("Failed to get comments")
-			return
-		}
-		if len(cts) > 0 {
-			for _, comment := range cts {
-				if ok, value := comments.ExtraIssueComment(comment.GetBody(), comments.IDPRCommitInvalid, comments.ExtraBotID); ok && comments.IDPRCommitInvalid.IsValid(value) {
-					if ok, value := comments.ExtraIssueComment(comment.GetBody(), comments.IDPRCommitInvalid, comments.ExtraCommitID); ok {
-						if _, ok := common.Find(allCommitsSHA, value); !ok {
-							// Delete commit for invalid commit message with commitID has been deleted
-							if err := core.ghc.DeleteComment(comment.GetID()); err != nil {
-								core.ghc.Logger.Error().Err(err).Msg("Failed to delete comment")
-							}
-						}
-					}
-				}
+	// Breaking change
+	if cm.IsBreakingChange() {
+		if _, err := core.ghc.GetLabel(labeler.BreakingChange.GetLongName()); err != nil {
+			if err := core.ghc.CreateLabel(labeler.BreakingChange.GithubLabel()); err != nil {
+				core.ghc.Logger.Error().Err(err).Msg("Failed to create label")
+				cacheable = false
+			} else {
+				verdict.LabelsType = append(verdict.LabelsType, labeler.BreakingChange.GetLongName())
 			}
+		} else {
+			verdict.LabelsType = append(verdict.LabelsType, labeler.BreakingChange.GetLongName())
+		}
+	}
+
+	return verdict, cacheable
+}
+
+// applyCommitVerdict applies the verdict of the commit to the status, the comments and the labels.
+// A cached verdict does not edit the comments already posted.
+func (core *corePR) applyCommitVerdict(commit *github.RepositoryCommit, verdict *db.CommitVerdict, cached bool) {
+	for _, l := range verdict.LabelsType {
+		if _, ok := common.Find(*core.labelsType, l); !ok {
+			*core.labelsType = append(*core.labelsType, l)
 		}
 	}
+	for _, l := range verdict.LabelsCategory {
+		if _, ok := common.Find(*core.labelsCategory, l); !ok {
+			*core.labelsCategory = append(*core.labelsCategory, l)
+		}
+	}
+
+	MsgPRCommitInvalid := comments.NewCommentMsg(core.ghc, comments.IDPRCommitInvalid, comments.PRCommitInvalidValues{
+		CommitMsg: commit.GetCommit().GetMessage(),
+		CommitSHA: commit.GetSHA(),
+	})
+	if MsgPRCommitInvalid == nil {
+		core.ghc.Logger.Error().Msg("Failed to create comment")
+		return
+	}
+
+	if verdict.Valid {
+		// Remove issue comment if commit message is valid
+		if err := MsgPRCommitInvalid.RemoveIssueComment(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to remove issue comment")
+		}
+		return
+	}
+
+	if err := core.PR_Check_commits.SetState(statustype.Failure); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+	}
+	if cached {
+		// The comment is created only if it was removed
+		if err := MsgPRCommitInvalid.CreateIssueComment(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to create issue comment")
+		}
+		return
+	}
+	if err := MsgPRCommitInvalid.EditIssueComment(); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to edit issue comment")
+	}
 }
 
 // CheckSizePR check size of PR
@@ -477,6 +1010,162 @@ func (core *corePR) CheckSizePR() {
 	}
 }
 
+// CheckDescription check if the description is valid
+// Check if the PullRequest description respect the pull request template of the repository.
+func (core *corePR) CheckDescription() {
+	tmpl, err := prtemplate.Load(core.ghc)
+	if err != nil {
+		if errors.Is(err, prtemplate.ErrTemplateNotFound) {
+			core.ghc.Logger.Debug().Msg("Repository has no pull request template")
+		} else {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to load pull request template")
+			if err := core.PR_Check_Description.SetState(statustype.Error); err != nil {
+				core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+			}
+		}
+		if err := core.PR_Check_Description.IsSuccess(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+		}
+		return
+	}
+
+	problems := tmpl.Check(core.ghc.GetPullRequest().GetBody(), prtemplate.Options{
+		RequiredSections:    core.config.PullRequestDescription.RequiredSections,
+		RequireCheckedTasks: core.config.PullRequestDescription.RequireCheckedTasks,
+	})
+
+	MsgPRDescriptionInvalid := comments.NewCommentMsg(core.ghc, comments.IDPRDescriptionInvalid, comments.PRDescriptionInvalidValues{
+		Template: tmpl.Path,
+		Problems: problems,
+	})
+	if MsgPRDescriptionInvalid == nil {
+		core.ghc.Logger.Error().Msg("Failed to create comment")
+		return
+	}
+
+	if len(problems) > 0 {
+		if err := core.PR_Check_Description.SetState(statustype.Failure); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+		}
+		if err := MsgPRDescriptionInvalid.EditIssueComment(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to edit issue comment")
+		}
+	} else {
+		if err := MsgPRDescriptionInvalid.RemoveIssueComment(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to remove issue comment")
+		}
+	}
+
+	if err := core.PR_Check_Description.IsSuccess(); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+	}
+}
+
+// CheckLinkedIssue check if the PR is linked to an open issue
+// Check the closing keywords of the PullRequest description and the footers of the commits
+// for the PR types which require a linked issue.
+func (core *corePR) CheckLinkedIssue() {
+	PrTitle, err := conventionalcommit.ParseCommit(core.Title())
+	if err != nil {
+		// PR title is checked by CheckTitle
+		if err := core.PR_Check_LinkedIssue.IsSuccess(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+		}
+		return
+	}
+
+	MsgPRLinkedIssueMissing := comments.NewCommentMsg(core.ghc, comments.IDPRLinkedIssueMissing, comments.PRLinkedIssueMissingValues{
+		Type: PrTitle.Type(),
+	})
+	if MsgPRLinkedIssueMissing == nil {
+		core.ghc.Logger.Error().Msg("Failed to create comment")
+		return
+	}
+
+	if _, ok := common.Find(core.config.LinkedIssue.Types, PrTitle.Type()); !ok {
+		if err := MsgPRLinkedIssueMissing.RemoveIssueComment(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to remove issue comment")
+		}
+		if err := core.PR_Check_LinkedIssue.IsSuccess(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+		}
+		return
+	}
+
+	// References of the description and of the commits footers
+	refs := issueref.FromText(core.ghc.GetPullRequest().GetBody())
+	commits, err := core.Commits()
+	if err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to get commits")
+	}
+	for _, commit := range commits {
+		if cm, err := conventionalcommit.ParseCommit(commit.GetCommit().GetMessage()); err == nil {
+			refs = append(refs, issueref.FromFooters(cm)...)
+		}
+	}
+
+	var (
+		linked bool
+		// lookupFailed is true when a reference could not be checked (ex: rate limit)
+		lookupFailed bool
+		problems     = make([]string, 0)
+		checked      = make(map[issueref.Ref]bool)
+	)
+	for _, ref := range refs {
+		r := ref.Resolve(core.ghc.GetRepoOwner(), core.ghc.GetRepoName())
+		if checked[r] {
+			continue
+		}
+		checked[r] = true
+
+		issue, err := core.ghc.GetIssueByNumber(r.Owner, r.Repo, r.Number)
+		switch {
+		case err != nil:
+			var errResp *github.ErrorResponse
+			if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound {
+				core.ghc.Logger.Debug().Err(err).Msgf("Issue %s not found", r)
+				problems = append(problems, fmt.Sprintf("`%s` does not exist", r))
+				continue
+			}
+			core.ghc.Logger.Error().Err(err).Msgf("Failed to get issue %s", r)
+			lookupFailed = true
+		case issue.IsPullRequest():
+			problems = append(problems, fmt.Sprintf("`%s` is a pull request", r))
+		case issue.GetState() != "open":
+			problems = append(problems, fmt.Sprintf("`%s` is closed", r))
+		default:
+			linked = true
+		}
+	}
+
+	switch {
+	case linked:
+		if err := MsgPRLinkedIssueMissing.RemoveIssueComment(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to remove issue comment")
+		}
+	case lookupFailed:
+		// The references are not blamed, the check is run again on the next event
+		if err := core.PR_Check_LinkedIssue.SetStateWithDescription(statustype.Error, "Failed to check the linked issues"); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+		}
+	default:
+		MsgPRLinkedIssueMissing = comments.NewCommentMsg(core.ghc, comments.IDPRLinkedIssueMissing, comments.PRLinkedIssueMissingValues{
+			Type:     PrTitle.Type(),
+			Problems: problems,
+		})
+		if err := core.PR_Check_LinkedIssue.SetState(statustype.Failure); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+		}
+		if err := MsgPRLinkedIssueMissing.EditIssueComment(); err != nil {
+			core.ghc.Logger.Error().Err(err).Msg("Failed to edit issue comment")
+		}
+	}
+
+	if err := core.PR_Check_LinkedIssue.IsSuccess(); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
+	}
+}
+
 // ComputeLabels compute labels.
 func (core *corePR) ComputeLabels() {
 	o := make([]string, 0)
@@ -486,7 +1175,7 @@ func (core *corePR) ComputeLabels() {
 
 	for _, lbl := range core.event.PullRequest.Labels {
 		core.ghc.Logger.Debug().Msgf("Label is %s", lbl.GetName())
-		if _, ok := common.Find(allLabels, lbl.GetName()); !ok {
+		if _, ok := common.Find(allLabels, lbl.GetName()); !ok && !core.dbEvent.IsLabelAddedByUser(lbl.GetName()) {
 			if err := core.ghc.RemoveLabelForIssue(lbl.GetName()); err != nil {
 				if err := core.PR_Labeler.SetState(statustype.Failure); err != nil {
 					core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
@@ -498,7 +1187,7 @@ func (core *corePR) ComputeLabels() {
 	}
 
 	for _, lbl := range allLabels {
-		if _, ok := common.Find(o, lbl); !ok {
+		if _, ok := common.Find(o, lbl); !ok && !core.dbEvent.IsLabelRemovedByUser(lbl) {
 			if err := core.ghc.AddLabelToIssue(lbl); err != nil {
 				if err := core.PR_Labeler.SetState(statustype.Failure); err != nil {
 					core.ghc.Logger.Error().Err(err).Msg("Failed to set status")
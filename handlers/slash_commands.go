@@ -0,0 +1,494 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/azrod/common-go"
+	"github.com/google/go-github/v47/github"
+	"github.com/pkg/errors"
+
+	"github.com/FrangipaneTeam/crown/handlers/comments"
+	"github.com/FrangipaneTeam/crown/pkg/conventionalcommit"
+	"github.com/FrangipaneTeam/crown/pkg/labeler"
+	"github.com/FrangipaneTeam/crown/pkg/slashcommand"
+	"github.com/FrangipaneTeam/crown/pkg/tracker"
+)
+
+// slashCommands returns the registry of the slash commands.
+func (core *coreIssueComment) slashCommands() *slashcommand.Registry {
+	r := slashcommand.NewRegistry()
+
+	r.Register(slashcommand.Command{
+		Name:        "label",
+		Verbs:       []string{"add", "remove"},
+		DefaultVerb: "add",
+		Args:        slashcommand.Args{Min: 1, Max: -1, Usage: "<label>..."},
+		Permission:  slashcommand.Permission{Level: slashcommand.LevelTriage},
+		Description: "Add or remove labels, a label without namespace is a category",
+		Handler:     core.cmdLabel,
+	})
+	r.Register(slashcommand.Command{
+		Name:        "track",
+		Args:        slashcommand.Args{Min: 1, Max: 1, Usage: "<owner/repo#id>"},
+		Permission:  slashcommand.Permission{Level: slashcommand.LevelTriage},
+		Description: "Track an upstream issue",
+		Handler:     core.cmdTrack,
+	})
+	r.Register(slashcommand.Command{
+		Name:            "dco",
+		Verbs:           []string{"override"},
+		Permission:      slashcommand.Permission{Level: slashcommand.LevelMaintain},
+		PullRequestOnly: true,
+		Description:     "Override the DCO check until new commits are pushed",
+		Handler:         core.cmdDCO,
+	})
+	r.Register(slashcommand.Command{
+		Name:            "recheck",
+		Args:            slashcommand.Args{Min: 0, Max: len(RecheckSteps), Usage: "[" + strings.Join(RecheckSteps, "|") + "]..."},
+		Permission:      slashcommand.Permission{Level: slashcommand.LevelWrite, Author: true},
+		PullRequestOnly: true,
+		Description:     "Re-run the checks of the pull request",
+		Handler:         core.cmdRecheck,
+	})
+	r.Register(slashcommand.Command{
+		Name:            "retitle",
+		Args:            slashcommand.Args{Min: 1, Max: -1, Usage: "<title>|suggest"},
+		Permission:      slashcommand.Permission{Level: slashcommand.LevelMaintain, Author: true},
+		PullRequestOnly: true,
+		Description:     "Rewrite the title of the pull request, or suggest one from the commits",
+		Handler:         core.cmdRetitle,
+	})
+	r.Register(slashcommand.Command{
+		Name:        "assign",
+		Args:        slashcommand.Args{Min: 0, Max: -1, Usage: "[@user]..."},
+		Permission:  slashcommand.Permission{Level: slashcommand.LevelTriage},
+		Description: "Assign the users, or yourself without argument",
+		Handler:     core.cmdAssign,
+	})
+	r.Register(slashcommand.Command{
+		Name:        "unassign",
+		Args:        slashcommand.Args{Min: 0, Max: -1, Usage: "[@user]..."},
+		Permission:  slashcommand.Permission{Level: slashcommand.LevelTriage},
+		Description: "Unassign the users, or yourself without argument",
+		Handler:     core.cmdUnassign,
+	})
+	r.Register(slashcommand.Command{
+		Name:            "cc",
+		Args:            slashcommand.Args{Min: 0, Max: -1, Usage: "[@user|@org/team]..."},
+		Permission:      slashcommand.Permission{Level: slashcommand.LevelTriage, Author: true},
+		PullRequestOnly: true,
+		Description:     "Request a review from the users and teams, or yourself without argument",
+		Handler:         core.cmdCC,
+	})
+	r.Register(slashcommand.Command{
+		Name:            "uncc",
+		Args:            slashcommand.Args{Min: 0, Max: -1, Usage: "[@user|@org/team]..."},
+		Permission:      slashcommand.Permission{Level: slashcommand.LevelTriage, Author: true},
+		PullRequestOnly: true,
+		Description:     "Remove the review requests of the users and teams, or yourself without argument",
+		Handler:         core.cmdUnCC,
+	})
+	r.Register(slashcommand.Command{
+		Name:            "hold",
+		Args:            slashcommand.Args{Min: 0, Max: -1, Usage: "[reason]"},
+		Permission:      slashcommand.Permission{Level: slashcommand.LevelTriage, Author: true},
+		PullRequestOnly: true,
+		Description:     "Block the merge of the pull request",
+		Handler:         core.cmdHold,
+	})
+	r.Register(slashcommand.Command{
+		Name:            "unhold",
+		Permission:      slashcommand.Permission{Level: slashcommand.LevelTriage, Author: true},
+		PullRequestOnly: true,
+		Description:     "Remove the hold of the pull request, the author can only remove their own hold",
+		Handler:         core.cmdUnhold,
+	})
+	r.Register(slashcommand.Command{
+		Name:            "lgtm",
+		Args:            slashcommand.Args{Min: 0, Max: 1, Usage: "[cancel]"},
+		Permission:      slashcommand.Permission{Level: slashcommand.LevelWrite},
+		PullRequestOnly: true,
+		Description:     "Add the lgtm label and approve the pull request, new commits reset it",
+		Handler:         core.cmdLGTM,
+	})
+	r.Register(slashcommand.Command{
+		Name:            "approve",
+		Args:            slashcommand.Args{Min: 0, Max: 1, Usage: "[cancel]"},
+		Permission:      slashcommand.Permission{},
+		PullRequestOnly: true,
+		Description:     "Approve the pull request, you must own all the changed files (OWNERS or CODEOWNERS)",
+		Handler:         core.cmdApprove,
+	})
+	r.Register(slashcommand.Command{
+		Name:        "close",
+		Args:        slashcommand.Args{Min: 0, Max: 1, Usage: "[" + strings.Join(closeReasons, "|") + "]"},
+		Permission:  slashcommand.Permission{Level: slashcommand.LevelTriage, Author: true},
+		Description: "Close the issue or the pull request",
+		Handler:     core.cmdClose,
+	})
+	r.Register(slashcommand.Command{
+		Name:        "reopen",
+		Permission:  slashcommand.Permission{Level: slashcommand.LevelTriage, Author: true},
+		Description: "Reopen the issue or the pull request",
+		Handler:     core.cmdReopen,
+	})
+	r.Register(slashcommand.Command{
+		Name:        "lock",
+		Args:        slashcommand.Args{Min: 0, Max: 2, Usage: "[" + strings.Join(lockReasons, "|") + "]"},
+		Permission:  slashcommand.Permission{Level: slashcommand.LevelTriage},
+		Description: "Lock the conversation",
+		Handler:     core.cmdLock,
+	})
+	r.Register(slashcommand.Command{
+		Name:        "milestone",
+		Args:        slashcommand.Args{Min: 1, Max: -1, Usage: "<title>|clear"},
+		Permission:  slashcommand.Permission{Level: slashcommand.LevelTriage},
+		Description: "Set the milestone by its title, or remove it",
+		Handler:     core.cmdMilestone,
+	})
+	r.Register(slashcommand.Command{
+		Name:            "cherry-pick",
+		Args:            slashcommand.Args{Min: 1, Max: 1, Usage: "<branch>"},
+		Permission:      slashcommand.Permission{Level: slashcommand.LevelWrite},
+		PullRequestOnly: true,
+		Description:     "Cherry-pick the merged pull request on the branch, or once merged",
+		Handler:         core.cmdCherryPick,
+	})
+	r.Register(slashcommand.Command{
+		Name:        "help",
+		Permission:  slashcommand.Permission{},
+		Description: "Display this help",
+		Handler: func(_ slashcommand.Invocation) error {
+			return core.cmdHelp(r)
+		},
+	})
+
+	core.applyPermissionsConfig(r)
+
+	return r
+}
+
+// runSlashCommands runs the slash commands of the comment.
+// Each command is acknowledged with a reaction on the comment.
+func (core *coreIssueComment) runSlashCommands(body string) {
+	var (
+		r         = core.slashCommands()
+		commentID = core.event.GetComment().GetID()
+		login     = core.event.GetComment().GetUser().GetLogin()
+	)
+
+	for i, inv := range slashcommand.Parse(body) {
+		core.replyTo = fmt.Sprintf("%d-%d", commentID, i)
+
+		cmd, inv, err := r.Resolve(inv)
+		if err != nil {
+			if cmd == nil {
+				// Not a crown command (ex: a path)
+				core.ghc.Logger.Debug().Str("command", inv.Line).Msg("Unknown slash command")
+				continue
+			}
+			core.replyInvalidCommand(inv, cmd, err.Error())
+			continue
+		}
+
+		if cmd.PullRequestOnly && !core.event.GetIssue().IsPullRequest() {
+			core.replyInvalidCommand(inv, cmd, "this command can only be run on a pull request")
+			continue
+		}
+
+		if ok, err := core.isAllowed(login, cmd.Permission); err != nil || !ok {
+			core.ghc.Logger.Debug().Err(err).Msgf("User %s is not allowed to run %s", login, cmd.Name)
+			core.replyRefusedCommand(inv, cmd, login)
+			continue
+		}
+
+		core.ghc.Logger.Debug().Msgf("Found slash command %s with verb %s from %s", cmd.Name, inv.Verb, login)
+		if err := cmd.Handler(inv); err != nil {
+			var uerr *slashcommand.UsageError
+			if errors.As(err, &uerr) {
+				core.replyInvalidCommand(inv, cmd, uerr.Error())
+				continue
+			}
+			core.ghc.Logger.Error().Err(err).Str("command", inv.Line).Msg("Failed to run slash command")
+			core.react(commentID, "-1")
+			continue
+		}
+		core.react(commentID, "+1")
+	}
+}
+
+// react adds a reaction on the comment.
+func (core *coreIssueComment) react(commentID int64, reaction string) {
+	if err := core.ghc.AddCommentReaction(commentID, reaction); err != nil {
+		core.ghc.Logger.Err(err).Msg("failed to add reaction")
+	}
+}
+
+// replyInvalidCommand replies with the usage of the command.
+func (core *coreIssueComment) replyInvalidCommand(inv slashcommand.Invocation, cmd *slashcommand.Command, reason string) {
+	core.react(core.event.GetComment().GetID(), "confused")
+
+	MsgSlashCommandInvalid := comments.NewCommentMsg(core.ghc, comments.IDSlashCommandInvalid, comments.SlashCommandInvalidValues{
+		ReplyTo: core.replyTo,
+		User:    core.event.GetComment().GetUser().GetLogin(),
+		Command: inv.Line,
+		Error:   reason,
+		Usage:   cmd.Usage(),
+	})
+	if MsgSlashCommandInvalid == nil {
+		core.ghc.Logger.Error().Msg("Failed to create comment")
+		return
+	}
+	if err := MsgSlashCommandInvalid.CreateIssueComment(); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to create issue comment")
+	}
+}
+
+// replyRefusedCommand explains why the command was refused.
+func (core *coreIssueComment) replyRefusedCommand(inv slashcommand.Invocation, cmd *slashcommand.Command, login string) {
+	core.react(core.event.GetComment().GetID(), "-1")
+
+	MsgSlashCommandRefused := comments.NewCommentMsg(core.ghc, comments.IDSlashCommandRefused, comments.SlashCommandRefusedValues{
+		ReplyTo: core.replyTo,
+		User:    login,
+		Command: inv.Line,
+		Allowed: cmd.Permission.Describe(),
+	})
+	if MsgSlashCommandRefused == nil {
+		core.ghc.Logger.Error().Msg("Failed to create comment")
+		return
+	}
+	if err := MsgSlashCommandRefused.CreateIssueComment(); err != nil {
+		core.ghc.Logger.Error().Err(err).Msg("Failed to create issue comment")
+	}
+}
+
+// cmdLabel adds or removes labels.
+// A label without namespace is a category label (ex: /label api is category/api),
+// other labels must be declared in the configuration.
+func (core *coreIssueComment) cmdLabel(inv slashcommand.Invocation) error {
+	switch inv.Verb {
+	case "add":
+		labels := make([]string, 0, len(inv.Args))
+		for _, arg := range inv.Args {
+			label, err := core.resolveLabel(arg)
+			if err != nil {
+				return err
+			}
+
+			if _, err := core.ghc.GetLabel(label.GetName()); err != nil {
+				if err := core.ghc.CreateLabel(label); err != nil {
+					return err
+				}
+			}
+			labels = append(labels, label.GetName())
+		}
+
+		if err := core.ghc.AddLabelsToIssue(labels); err != nil {
+			return err
+		}
+
+		for _, l := range labels {
+			core.dbEvent.UserLabeled(l)
+
+			// Remove comment if label added is in comment
+			MsgPRIssuesLabelNotExists := comments.NewCommentMsg(core.ghc, comments.IDIssuesLabelNotExists, comments.IssuesLabelNotExistsValues{
+				Label: l,
+			})
+			if err := MsgPRIssuesLabelNotExists.RemoveIssueComment(); err != nil {
+				core.ghc.Logger.Err(err).Msg("failed to remove comment")
+			}
+		}
+
+	case "remove":
+		for _, arg := range inv.Args {
+			name, ok := core.findIssueLabel(arg)
+			if !ok {
+				return slashcommand.NewUsageError("label `%s` is not set", arg)
+			}
+
+			if err := core.ghc.RemoveLabelForIssue(name); err != nil {
+				return err
+			}
+			core.dbEvent.UserUnlabeled(name)
+		}
+	}
+
+	// The next checks respect the labels set by the user
+	return core.WriteDB()
+}
+
+// resolveLabel returns the label of the argument of /label.
+func (core *coreIssueComment) resolveLabel(arg string) (github.Label, error) {
+	if d, ok := core.config.Labels.Find(arg); ok {
+		label := github.Label{
+			Name: github.String(d.Name),
+		}
+		if d.Color != "" {
+			label.Color = github.String(d.Color)
+		}
+		if d.Description != "" {
+			label.Description = github.String(d.Description)
+		}
+		return label, nil
+	}
+
+	if !strings.Contains(arg, "/") || labeler.IsLabelScope(arg) {
+		return labeler.LabelScope(arg).GithubLabel(), nil
+	}
+
+	return github.Label{}, slashcommand.NewUsageError("label `%s` is not declared", arg)
+}
+
+// findIssueLabel returns the name of the label of the issue matching the argument of /label.
+// Any label of the issue can be removed, declared or not.
+func (core *coreIssueComment) findIssueLabel(arg string) (string, bool) {
+	candidates := []string{arg}
+	if label, err := core.resolveLabel(arg); err == nil {
+		candidates = append(candidates, label.GetName())
+	}
+
+	for _, l := range core.event.GetIssue().Labels {
+		for _, c := range candidates {
+			if strings.EqualFold(l.GetName(), c) {
+				return l.GetName(), true
+			}
+		}
+	}
+	return "", false
+}
+
+// cmdTrack tracks an upstream issue.
+func (core *coreIssueComment) cmdTrack(inv slashcommand.Invocation) error {
+	ref, err := tracker.ParseReference(inv.Args[0])
+	if err != nil {
+		return err
+	}
+
+	return trackReference(core.ghc, ref)
+}
+
+// cmdDCO overrides the DCO check.
+func (core *coreIssueComment) cmdDCO(_ slashcommand.Invocation) error {
+	return core.OverrideDCO(core.event.GetComment().GetUser().GetLogin())
+}
+
+// cmdRecheck re-runs the checks of the pull request on its current head.
+func (core *coreIssueComment) cmdRecheck(inv slashcommand.Invocation) error {
+	steps := make([]string, 0, len(inv.Args))
+	for _, arg := range inv.Args {
+		step := strings.ToLower(arg)
+		if _, ok := common.Find(RecheckSteps, step); !ok {
+			return slashcommand.NewUsageError("unknown step `%s`", arg)
+		}
+		steps = append(steps, step)
+	}
+
+	pr, err := core.ghc.FetchPullRequest()
+	if err != nil {
+		return err
+	}
+	if pr.GetState() != "open" {
+		return slashcommand.NewUsageError("the pull request is %s", pr.GetState())
+	}
+
+	// The pull request event is rebuilt from the current pull request
+	newCorePR(core.ghc, core.config, github.PullRequestEvent{
+		Action:       github.String(ActionRecheck),
+		Number:       github.Int(pr.GetNumber()),
+		PullRequest:  pr,
+		Repo:         core.event.GetRepo(),
+		Sender:       core.event.GetSender(),
+		Installation: core.event.GetInstallation(),
+	}).Recheck(steps)
+
+	return nil
+}
+
+// cmdRetitle rewrites the title of the pull request.
+// The title must respect the conventional commit format.
+func (core *coreIssueComment) cmdRetitle(inv slashcommand.Invocation) error {
+	if len(inv.Args) == 1 && strings.EqualFold(inv.Args[0], "suggest") {
+		return core.suggestTitle()
+	}
+
+	title := inv.Raw
+	if len(inv.Args) == 1 {
+		// The title may be quoted
+		title = inv.Args[0]
+	}
+	cm, err := conventionalcommit.ParseCommit(title)
+	if err != nil {
+		return slashcommand.NewUsageError("`%s` is not a conventional commit title (ex: feat(api): add pagination)", title)
+	}
+	if _, ok := labeler.FindLabelerType(cm); !ok {
+		return slashcommand.NewUsageError("type `%s` is not allowed", cm.Type())
+	}
+
+	// The edited event of the pull request runs the checks
+	return core.ghc.EditPullRequestTitle(title)
+}
+
+// suggestTitle replies with a title derived from the dominant type and scope of the valid commits.
+func (core *coreIssueComment) suggestTitle() error {
+	pr, err := core.ghc.FetchPullRequest()
+	if err != nil {
+		return err
+	}
+
+	commits, err := core.ghc.GetCommits()
+	if err != nil {
+		return err
+	}
+
+	valid := make([]*conventionalcommit.Cc, 0, len(commits))
+	for _, c := range commits {
+		cm, err := conventionalcommit.ParseCommit(c.GetCommit().GetMessage())
+		if err != nil {
+			continue
+		}
+		if _, ok := labeler.FindLabelerType(cm); ok {
+			valid = append(valid, cm)
+		}
+	}
+	if len(valid) == 0 {
+		return slashcommand.NewUsageError("no commit respects the conventional commit format")
+	}
+
+	// The description of the current title is kept if possible
+	description := conventionalcommit.TrimWIP(pr.GetTitle())
+	if cm, err := conventionalcommit.ParseCommit(description); err == nil {
+		description = cm.Description()
+	}
+	if description == "" {
+		description = valid[len(valid)-1].Description()
+	}
+
+	title, _ := conventionalcommit.SuggestHeader(valid, description)
+
+	MsgPRRetitleSuggestion := comments.NewCommentMsg(core.ghc, comments.IDPRRetitleSuggestion, comments.PRRetitleSuggestionValues{
+		ReplyTo: core.replyTo,
+		User:    core.event.GetComment().GetUser().GetLogin(),
+		Title:   title,
+	})
+	if MsgPRRetitleSuggestion == nil {
+		return fmt.Errorf("failed to create comment")
+	}
+
+	return MsgPRRetitleSuggestion.CreateIssueComment()
+}
+
+// cmdHelp replies with the list of the commands.
+func (core *coreIssueComment) cmdHelp(r *slashcommand.Registry) error {
+	MsgSlashCommandHelp := comments.NewCommentMsg(core.ghc, comments.IDSlashCommandHelp, comments.SlashCommandHelpValues{
+		ReplyTo: core.replyTo,
+		User:    core.event.GetComment().GetUser().GetLogin(),
+		Help:    r.Help(),
+	})
+	if MsgSlashCommandHelp == nil {
+		return fmt.Errorf("failed to create comment")
+	}
+
+	return MsgSlashCommandHelp.CreateIssueComment()
+}
@@ -16,6 +16,15 @@ const (
 
 	Issue_Check_Title
 	Issue_Labeler
+
+	PR_Check_Description
+	PR_Check_LinkedIssue
+	PR_Check_DCO
+	PR_Check_Signature
+	PR_Check_Branch
+	PR_WIP
+	PR_Hold
+	PR_Approved
 )
 
 //go:generate stringer -type=StatusCategory
@@ -102,6 +111,96 @@ var listOfStatuses = map[StatusCategory]Status{
 			Pending: "Labeling issue",
 		},
 	},
+	PR_Check_Description: {
+		repoStatus: github.RepoStatus{
+			State:   github.String(status.Pending.String()),
+			Context: github.String(PR_Check_Description.String()),
+		},
+		statusMessages: statusMessages{
+			Success: "PR description is valid",
+			Failure: "PR description does not follow the template",
+			Pending: "Checking PR description",
+		},
+	},
+	PR_Check_LinkedIssue: {
+		repoStatus: github.RepoStatus{
+			State:   github.String(status.Pending.String()),
+			Context: github.String(PR_Check_LinkedIssue.String()),
+		},
+		statusMessages: statusMessages{
+			Success: "PR is linked to an open issue",
+			Failure: "PR is not linked to an open issue",
+			Pending: "Checking PR linked issue",
+		},
+	},
+	PR_Check_DCO: {
+		repoStatus: github.RepoStatus{
+			State:   github.String(status.Pending.String()),
+			Context: github.String(PR_Check_DCO.String()),
+		},
+		statusMessages: statusMessages{
+			Success: "All commits are signed off",
+			Failure: "PR has commits without sign-off",
+			Pending: "Checking commits sign-off",
+		},
+	},
+	PR_Check_Signature: {
+		repoStatus: github.RepoStatus{
+			State:   github.String(status.Pending.String()),
+			Context: github.String(PR_Check_Signature.String()),
+		},
+		statusMessages: statusMessages{
+			Success: "All commits have a verified signature",
+			Failure: "PR has commits without verified signature",
+			Pending: "Checking commits signature",
+		},
+	},
+	PR_Check_Branch: {
+		repoStatus: github.RepoStatus{
+			State:   github.String(status.Pending.String()),
+			Context: github.String(PR_Check_Branch.String()),
+		},
+		statusMessages: statusMessages{
+			Success: "Branch name is valid",
+			Failure: "Branch name is invalid",
+			Pending: "Checking branch name",
+		},
+	},
+	PR_WIP: {
+		repoStatus: github.RepoStatus{
+			State: github.String(status.Pending.String()),
+			// Same context as the Prow plugin, some tools already know it
+			Context: github.String("do-not-merge/wip"),
+		},
+		statusMessages: statusMessages{
+			Success: "PR is not a work in progress",
+			Failure: "PR title is prefixed with WIP",
+			Pending: "Checking WIP prefix",
+		},
+	},
+	PR_Hold: {
+		repoStatus: github.RepoStatus{
+			State: github.String(status.Pending.String()),
+			// Same context as the Prow plugin, some tools already know it
+			Context: github.String("do-not-merge/hold"),
+		},
+		statusMessages: statusMessages{
+			Success: "PR is not on hold",
+			Failure: "PR is on hold",
+			Pending: "Checking hold",
+		},
+	},
+	PR_Approved: {
+		repoStatus: github.RepoStatus{
+			State:   github.String(status.Pending.String()),
+			Context: github.String("approved"),
+		},
+		statusMessages: statusMessages{
+			Success: "PR is approved by an owner",
+			Failure: "PR is not approved",
+			Pending: "Waiting for /approve from an owner of the changed files",
+		},
+	},
 }
 
 // NewStatus returns a new status.
@@ -134,6 +233,14 @@ func (s *Status) SetState(state status.Status) error {
 	return s.ghc.EditStatus(s.repoStatus, s.commitSHA)
 }
 
+// SetStateWithDescription sets the state of the status with a custom description.
+func (s *Status) SetStateWithDescription(state status.Status, description string) error {
+	s.repoStatus.State = github.String(state.String())
+	s.repoStatus.Description = github.String(description)
+
+	return s.ghc.EditStatus(s.repoStatus, s.commitSHA)
+}
+
 // IsSuccess sets the state of the status to success if state is not failure or error.
 func (s *Status) IsSuccess() error {
 	if status.Status(*s.repoStatus.State) == status.Failure || status.Status(*s.repoStatus.State) == status.Error {
@@ -14,15 +14,31 @@ func _() {
 	_ = x[PR_Labeler-792]
 	_ = x[Issue_Check_Title-1584]
 	_ = x[Issue_Labeler-3168]
+	_ = x[PR_Check_Description-6336]
+	_ = x[PR_Check_LinkedIssue-12672]
+	_ = x[PR_Check_DCO-25344]
+	_ = x[PR_Check_Signature-50688]
+	_ = x[PR_Check_Branch-101376]
+	_ = x[PR_WIP-202752]
+	_ = x[PR_Hold-405504]
+	_ = x[PR_Approved-811008]
 }
 
 const (
-	_StatusCategory_name_0 = "PR_Check_Title"
-	_StatusCategory_name_1 = "PR_Check_Commits"
-	_StatusCategory_name_2 = "PR_Check_SizeChanges"
-	_StatusCategory_name_3 = "PR_Labeler"
-	_StatusCategory_name_4 = "Issue_Check_Title"
-	_StatusCategory_name_5 = "Issue_Labeler"
+	_StatusCategory_name_0  = "PR_Check_Title"
+	_StatusCategory_name_1  = "PR_Check_Commits"
+	_StatusCategory_name_2  = "PR_Check_SizeChanges"
+	_StatusCategory_name_3  = "PR_Labeler"
+	_StatusCategory_name_4  = "Issue_Check_Title"
+	_StatusCategory_name_5  = "Issue_Labeler"
+	_StatusCategory_name_6  = "PR_Check_Description"
+	_StatusCategory_name_7  = "PR_Check_LinkedIssue"
+	_StatusCategory_name_8  = "PR_Check_DCO"
+	_StatusCategory_name_9  = "PR_Check_Signature"
+	_StatusCategory_name_10 = "PR_Check_Branch"
+	_StatusCategory_name_11 = "PR_WIP"
+	_StatusCategory_name_12 = "PR_Hold"
+	_StatusCategory_name_13 = "PR_Approved"
 )
 
 func (i StatusCategory) String() string {
@@ -39,6 +55,22 @@ func (i StatusCategory) String() string {
 		return _StatusCategory_name_4
 	case i == 3168:
 		return _StatusCategory_name_5
+	case i == 6336:
+		return _StatusCategory_name_6
+	case i == 12672:
+		return _StatusCategory_name_7
+	case i == 25344:
+		return _StatusCategory_name_8
+	case i == 50688:
+		return _StatusCategory_name_9
+	case i == 101376:
+		return _StatusCategory_name_10
+	case i == 202752:
+		return _StatusCategory_name_11
+	case i == 405504:
+		return _StatusCategory_name_12
+	case i == 811008:
+		return _StatusCategory_name_13
 	default:
 		return "StatusCategory(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/FrangipaneTeam/crown/handlers/comments"
+	"github.com/FrangipaneTeam/crown/pkg/ghclient"
+	"github.com/FrangipaneTeam/crown/pkg/tracker"
+)
+
+// autoTrack tracks the upstream issues referenced with a tracking keyword in the body
+// and posts a confirmation on the issue.
+func autoTrack(ghc *ghclient.GHClient, body string) {
+	for _, ref := range tracker.FindReferences(body) {
+		// Only upstream issues are tracked
+		if strings.EqualFold(ref.GetRepoOwner(), ghc.GetRepoOwner()) && strings.EqualFold(ref.GetRepoName(), ghc.GetRepoName()) {
+			continue
+		}
+
+		if err := trackReference(ghc, ref); err != nil {
+			ghc.Logger.Error().Err(err).Str("reference", ref.String()).Msg("Failed to track issue")
+		}
+	}
+}
+
+// trackReference tracks the upstream issue and posts a confirmation on the issue.
+func trackReference(ghc *ghclient.GHClient, ref tracker.GithubRepository) error {
+	source := tracker.TypeIssue
+	if ghc.GetIssue().IsPullRequest() {
+		source = tracker.TypePR
+	}
+
+	if err := tracker.TrackNewIssue(source, ghc.GetRepoOwner(), ghc.GetRepoName(), ghc.GetInstallationID(), int64(ghc.GetIssueNumber()), ref.String()); err != nil {
+		return err
+	}
+
+	MsgIssuesTrackConfirmed := comments.NewCommentMsg(ghc, comments.IDIssuesTrackConfirmed, comments.IssuesTrackConfirmedValues{
+		Target: ref.String(),
+	})
+	if MsgIssuesTrackConfirmed == nil {
+		return errors.New("failed to create comment")
+	}
+
+	return MsgIssuesTrackConfirmed.CreateIssueComment()
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/azrod/common-go"
+
+	"github.com/FrangipaneTeam/crown/pkg/slashcommand"
+)
+
+// closeReasons are the reasons of /close.
+var closeReasons = []string{"completed", "not_planned"}
+
+// lockReasons are the reasons of /lock.
+var lockReasons = []string{"off-topic", "too heated", "resolved", "spam"}
+
+// cmdClose closes the issue or the pull request.
+func (core *coreIssueComment) cmdClose(inv slashcommand.Invocation) error {
+	if core.event.GetIssue().GetState() == "closed" {
+		return slashcommand.NewUsageError("already closed")
+	}
+
+	reason := ""
+	if len(inv.Args) == 1 {
+		reason = strings.ReplaceAll(strings.ToLower(inv.Args[0]), "-", "_")
+		if !isReason(closeReasons, reason) {
+			return slashcommand.NewUsageError("unknown reason `%s`, use one of %s", inv.Args[0], strings.Join(closeReasons, ", "))
+		}
+		if reason == "not_planned" && core.event.GetIssue().IsPullRequest() {
+			return slashcommand.NewUsageError("a pull request cannot be closed as not planned")
+		}
+	}
+
+	return core.ghc.CloseIssue(reason)
+}
+
+// cmdReopen reopens the issue or the pull request.
+func (core *coreIssueComment) cmdReopen(_ slashcommand.Invocation) error {
+	if core.event.GetIssue().GetState() == "open" {
+		return slashcommand.NewUsageError("already open")
+	}
+
+	return core.ghc.ReopenIssue()
+}
+
+// cmdLock locks the conversation.
+func (core *coreIssueComment) cmdLock(inv slashcommand.Invocation) error {
+	// The reason can be written too-heated or too_heated
+	reason := strings.NewReplacer("_", " ", "too-heated", "too heated").Replace(strings.ToLower(inv.Raw))
+	if reason != "" && !isReason(lockReasons, reason) {
+		return slashcommand.NewUsageError("unknown reason `%s`, use one of %s", inv.Raw, strings.Join(lockReasons, ", "))
+	}
+
+	return core.ghc.LockIssue(reason)
+}
+
+// cmdMilestone sets the milestone by its title, or removes it.
+func (core *coreIssueComment) cmdMilestone(inv slashcommand.Invocation) error {
+	title := inv.Raw
+	if len(inv.Args) == 1 {
+		// The title may be quoted
+		title = inv.Args[0]
+	}
+
+	if strings.EqualFold(title, "clear") {
+		return core.ghc.RemoveMilestone()
+	}
+
+	m, err := core.ghc.FindMilestone(title)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return slashcommand.NewUsageError("milestone `%s` does not exist or is closed", title)
+	}
+
+	return core.ghc.SetMilestone(m.GetNumber())
+}
+
+// isReason returns true if the reason is in the list.
+func isReason(reasons []string, reason string) bool {
+	_, ok := common.Find(reasons, reason)
+	return ok
+}
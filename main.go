@@ -60,9 +60,6 @@ func main() {
 		logger.Fatal().Err(err).Msg("Failed to open database")
 	}
 
-	tracker.Init(logger)
-	go tracker.Watch()
-
 	metricsRegistry := metrics.DefaultRegistry
 
 	cc, err := githubapp.NewDefaultCachingClientCreator(
@@ -78,11 +75,15 @@ func main() {
 		logger.Fatal().Err(err).Msg("Failed to create client githubApp")
 	}
 
+	tracker.Init(logger, cc, config.AppConfig.Tracker)
+	go tracker.Watch()
+	go db.WatchArchived(logger, config.DB.Retention)
+
 	webhookHandler := githubapp.NewEventDispatcher(
 		[]githubapp.EventHandler{
-			&handlers.PullRequestHandler{ClientCreator: cc},
-			&handlers.IssueCommentHandler{ClientCreator: cc},
-			// &handlers.IssuesHandler{ClientCreator: cc},
+			&handlers.PullRequestHandler{ClientCreator: cc, Config: &config.AppConfig},
+			&handlers.IssueCommentHandler{ClientCreator: cc, Config: &config.AppConfig},
+			&handlers.IssuesHandler{ClientCreator: cc, Config: &config.AppConfig},
 		},
 		config.Github.App.WebhookSecret,
 		githubapp.WithScheduler(
@@ -0,0 +1,151 @@
+package cherrypick
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/FrangipaneTeam/crown/pkg/ghclient"
+)
+
+// Cherry-pick with the Git Data API, no local clone is needed.
+// Each commit C with the parent P is applied on the tip T of the branch :
+//  1. a temporary commit with the tree of T and the parent P is set on the branch
+//  2. C is merged into the branch, the merge computes the tree of T with the changes of C (P..C)
+//  3. a commit with the tree of the merge and the parent T replaces the merge on the branch
+
+// ConflictError is returned when a commit does not apply cleanly.
+type ConflictError struct {
+	// SHA is the commit which does not apply
+	SHA string
+}
+
+// Error returns the message of the error.
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("commit %s does not apply cleanly", e.SHA)
+}
+
+// AsConflict returns the ConflictError of the error, if any.
+func AsConflict(err error) (*ConflictError, bool) {
+	var cerr *ConflictError
+	ok := errors.As(err, &cerr)
+	return cerr, ok
+}
+
+// Commits returns the commits to cherry-pick of the merged pull request, from the oldest to the newest.
+// A merge commit is picked against its first parent with the title of the pull request as message,
+// the commits of a rebase merge are picked one by one and a squash commit is picked as is.
+func Commits(ghc *ghclient.GHClient, pr *github.PullRequest) ([]*github.Commit, error) {
+	if !pr.GetMerged() {
+		return nil, errors.New("the pull request is not merged")
+	}
+
+	mc, err := ghc.GetGitCommit(pr.GetMergeCommitSHA())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mc.Parents) > 1 {
+		// The message of a merge commit is not a conventional commit
+		mc.Message = github.String(fmt.Sprintf("%s (#%d)", pr.GetTitle(), pr.GetNumber()))
+		return []*github.Commit{mc}, nil
+	}
+
+	prCommits, err := ghc.GetCommits()
+	if err != nil {
+		return nil, err
+	}
+	if len(prCommits) <= 1 || prCommits[len(prCommits)-1].GetCommit().GetMessage() != mc.GetMessage() {
+		// Squash merge
+		return []*github.Commit{mc}, nil
+	}
+
+	// Rebase merge, the commits of the pull request are the last commits of the base branch
+	commits := []*github.Commit{mc}
+	for len(commits) < len(prCommits) {
+		parent, err := ghc.GetGitCommit(commits[0].Parents[0].GetSHA())
+		if err != nil {
+			return nil, err
+		}
+		commits = append([]*github.Commit{parent}, commits...)
+	}
+
+	return commits, nil
+}
+
+// CherryPick creates the branch from the base branch and applies the commits on it.
+// The branch is deleted if a commit does not apply cleanly.
+func CherryPick(ghc *ghclient.GHClient, base, branch string, commits []*github.Commit) error {
+	b, err := ghc.GetBranch(base)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return fmt.Errorf("branch %s does not exist", base)
+	}
+
+	tip, err := ghc.GetGitCommit(b.GetCommit().GetSHA())
+	if err != nil {
+		return err
+	}
+
+	if err := ghc.CreateBranch(branch, tip.GetSHA()); err != nil {
+		return err
+	}
+
+	for _, c := range commits {
+		tip, err = pick(ghc, branch, tip, c)
+		if err != nil {
+			if derr := ghc.DeleteBranch(branch); derr != nil {
+				ghc.Logger.Error().Err(derr).Str("branch", branch).Msg("Failed to delete branch")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pick applies the commit on the tip of the branch and returns the new tip.
+func pick(ghc *ghclient.GHClient, branch string, tip, c *github.Commit) (*github.Commit, error) {
+	if len(c.Parents) == 0 {
+		return nil, fmt.Errorf("commit %s has no parent", c.GetSHA())
+	}
+
+	sibling, err := ghc.CreateGitCommit(&github.Commit{
+		Message: github.String("crown cherry-pick of " + c.GetSHA()),
+		Tree:    &github.Tree{SHA: tip.GetTree().SHA},
+		Parents: []*github.Commit{{SHA: c.Parents[0].SHA}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := ghc.UpdateBranch(branch, sibling.GetSHA()); err != nil {
+		return nil, err
+	}
+
+	merge, err := ghc.Merge(branch, c.GetSHA(), "crown cherry-pick of "+c.GetSHA())
+	if err != nil {
+		if errors.Is(err, ghclient.ErrMergeConflict) {
+			return nil, &ConflictError{SHA: c.GetSHA()}
+		}
+		return nil, err
+	}
+	if merge == nil {
+		// The changes are already on the branch
+		return tip, ghc.UpdateBranch(branch, tip.GetSHA())
+	}
+
+	picked, err := ghc.CreateGitCommit(&github.Commit{
+		Message: github.String(fmt.Sprintf("%s\n\n(cherry picked from commit %s)", c.GetMessage(), c.GetSHA())),
+		Tree:    &github.Tree{SHA: merge.GetCommit().GetTree().SHA},
+		Parents: []*github.Commit{{SHA: tip.SHA}},
+		Author:  c.Author,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return picked, ghc.UpdateBranch(branch, picked.GetSHA())
+}
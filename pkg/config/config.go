@@ -2,16 +2,15 @@ package config
 
 import (
 	"os"
+	"strings"
+	"time"
 
 	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 )
 
-var (
-	AppID      int64
-	PrivateKey []byte
-)
+var AppID int64
 
 type Config struct {
 	Server HTTPConfig       `yaml:"server"`
@@ -25,6 +24,8 @@ type Config struct {
 // DBConfig.
 type DBConfig struct {
 	Path string `yaml:"path"`
+	// Retention is the delay before purging the record of a pull request closed without merge
+	Retention time.Duration `yaml:"retention"`
 }
 
 type LogConfig struct {
@@ -39,6 +40,131 @@ type HTTPConfig struct {
 
 type CrownConfig struct {
 	PullRequestPreamble string `yaml:"pull_request_preamble"`
+
+	Tracker TrackerConfig `yaml:"tracker"`
+
+	PullRequestDescription PullRequestDescriptionConfig `yaml:"pull_request_description"`
+	LinkedIssue            LinkedIssueConfig            `yaml:"linked_issue"`
+	DCO                    DCOConfig                    `yaml:"dco"`
+	Signature              SignatureConfig              `yaml:"signature"`
+	BranchNaming           BranchNamingConfig           `yaml:"branch_naming"`
+	WIP                    WIPConfig                    `yaml:"wip"`
+	Approval               ApprovalConfig               `yaml:"approval"`
+	SlashCommands          SlashCommandsConfig          `yaml:"slash_commands"`
+	Labels                 LabelsConfig                 `yaml:"labels"`
+}
+
+type TrackerConfig struct {
+	// GracePeriod is the delay before untracking an item once it is closed
+	GracePeriod time.Duration `yaml:"grace_period"`
+	// Retention is the delay before purging an untracked item
+	Retention time.Duration `yaml:"retention"`
+	// AutoTrack enables the tracking of the issues referenced with a "depends on" or "blocked by" keyword
+	AutoTrack bool `yaml:"auto_track"`
+}
+
+type PullRequestDescriptionConfig struct {
+	// RequiredSections is the list of the sections of the template which must be filled, all the sections if empty
+	RequiredSections []string `yaml:"required_sections"`
+	// RequireCheckedTasks requires all the tasks of the checklists of the template to be checked
+	RequireCheckedTasks bool `yaml:"require_checked_tasks"`
+}
+
+type LinkedIssueConfig struct {
+	// Types is the list of the commit types of the PR title which require a linked issue, the check is disabled if empty
+	Types []string `yaml:"types"`
+}
+
+// IsEnabled returns true if the linked issue check is enabled.
+func (c LinkedIssueConfig) IsEnabled() bool {
+	return len(c.Types) > 0
+}
+
+type DCOConfig struct {
+	// Enabled requires a sign-off matching the author on every non-merge commit
+	Enabled bool `yaml:"enabled"`
+}
+
+type SignatureConfig struct {
+	// Enabled requires a verified signature on every commit
+	Enabled bool `yaml:"enabled"`
+	// AllowedBots is the list of the bot logins whose commits are not checked (ex: dependabot[bot])
+	AllowedBots []string `yaml:"allowed_bots"`
+}
+
+// IsAllowedBot returns true if the login is in the list of allowed bots.
+func (s SignatureConfig) IsAllowedBot(login string) bool {
+	for _, b := range s.AllowedBots {
+		if strings.EqualFold(b, login) {
+			return true
+		}
+	}
+	return false
+}
+
+type BranchNamingConfig struct {
+	// Enabled checks the name of the head branch (ex: feat/12-my-feature)
+	Enabled bool `yaml:"enabled"`
+	// Exempt is the list of the branch patterns which are not checked (ex: release/*)
+	Exempt []string `yaml:"exempt"`
+}
+
+type WIPConfig struct {
+	// Enabled sets the do-not-merge/wip status when the PR title is prefixed with WIP or [WIP]
+	Enabled bool `yaml:"enabled"`
+}
+
+type ApprovalConfig struct {
+	// Enabled enables the /lgtm and /approve commands and the approved status
+	Enabled bool `yaml:"enabled"`
+	// LGTMLabel is the label added by /lgtm, lgtm by default
+	LGTMLabel string `yaml:"lgtm_label"`
+}
+
+// GetLGTMLabel returns the label added by /lgtm.
+func (c ApprovalConfig) GetLGTMLabel() string {
+	if c.LGTMLabel == "" {
+		return "lgtm"
+	}
+	return c.LGTMLabel
+}
+
+type SlashCommandsConfig struct {
+	// Permissions overrides the default permission of the commands, the key is the name of the command
+	Permissions map[string]PermissionConfig `yaml:"permissions"`
+}
+
+type PermissionConfig struct {
+	// Level is the minimum permission level on the repository (none, read, triage, write, maintain, admin)
+	Level string `yaml:"level"`
+	// Teams is the list of the teams allowed (ex: FrangipaneTeam/maintainers)
+	Teams []string `yaml:"teams"`
+	// CodeOwners allows the owners of all the files changed by the pull request
+	CodeOwners bool `yaml:"code_owners"`
+	// Author allows the author of the issue or the pull request
+	Author bool `yaml:"author"`
+}
+
+type LabelsConfig struct {
+	// Declared is the list of the labels which can be set with the /label command
+	Declared []LabelConfig `yaml:"declared"`
+}
+
+type LabelConfig struct {
+	// Name is the name of the label, it may have a namespace (ex: priority/high)
+	Name        string `yaml:"name"`
+	Color       string `yaml:"color"`
+	Description string `yaml:"description"`
+}
+
+// Find returns the declared label with the name, the case is ignored.
+func (l LabelsConfig) Find(name string) (LabelConfig, bool) {
+	for _, x := range l.Declared {
+		if strings.EqualFold(x.Name, name) {
+			return x, true
+		}
+	}
+	return LabelConfig{}, false
 }
 
 func ReadConfig(path string) (*Config, error) {
@@ -57,8 +183,19 @@ func ReadConfig(path string) (*Config, error) {
 		c.Log.Level = "info"
 	}
 
+	if c.AppConfig.Tracker.GracePeriod == 0 {
+		c.AppConfig.Tracker.GracePeriod = 7 * 24 * time.Hour
+	}
+
+	if c.AppConfig.Tracker.Retention == 0 {
+		c.AppConfig.Tracker.Retention = 30 * 24 * time.Hour
+	}
+
+	if c.DB.Retention == 0 {
+		c.DB.Retention = 30 * 24 * time.Hour
+	}
+
 	AppID = c.Github.App.IntegrationID
-	PrivateKey = []byte(c.Github.App.PrivateKey)
 
 	return &c, nil
 }
@@ -0,0 +1,91 @@
+package conventionalbranch
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/FrangipaneTeam/crown/pkg/common"
+	"github.com/FrangipaneTeam/crown/pkg/labeler"
+)
+
+// Patterns are the formats of a branch name.
+var Patterns = []string{
+	"<type>/<short-desc>",
+	"<type>/<issue>-<short-desc>",
+}
+
+// branchRe matches the branch name format "<type>/<issue>-<short-desc>", the issue is optional.
+var branchRe = regexp.MustCompile(`^(?P<type>[a-z]+)/(?:(?P<issue>\d+)-)?(?P<desc>[a-z0-9]+(?:[-_.][a-z0-9]+)*)$`)
+
+var (
+	ErrInvalidFormat = errors.New("invalid branch name format")
+	ErrUnknownType   = errors.New("unknown branch type")
+)
+
+type Branch struct {
+	labelerType labeler.LabelerType
+	issue       int
+	description string
+}
+
+// ParseBranch parses a branch name and returns a conventional branch.
+func ParseBranch(name string) (*Branch, error) {
+	m := common.ReSubMatchMap(branchRe, name)
+	if len(m) == 0 {
+		return nil, ErrInvalidFormat
+	}
+
+	t, ok := labeler.FindLabelerTypeByShortName(m["type"])
+	if !ok {
+		return nil, ErrUnknownType
+	}
+
+	b := &Branch{
+		labelerType: t,
+		description: m["desc"],
+	}
+
+	if m["issue"] != "" {
+		b.issue, _ = strconv.Atoi(m["issue"])
+	}
+
+	return b, nil
+}
+
+// Type returns the type of the branch.
+func (b *Branch) Type() labeler.LabelerType {
+	return b.labelerType
+}
+
+// Issue returns the issue number of the branch, 0 if the branch has no issue.
+func (b *Branch) Issue() int {
+	return b.issue
+}
+
+// Description returns the short description of the branch.
+func (b *Branch) Description() string {
+	return b.description
+}
+
+// Types returns the sorted list of the allowed branch types.
+func Types() []string {
+	x := make([]string, 0, len(labeler.LabelsType))
+	for k := range labeler.LabelsType {
+		x = append(x, k.GetShortName())
+	}
+	sort.Strings(x)
+	return x
+}
+
+// IsExempt returns true if the branch name matches one of the patterns (ex: release/*).
+func IsExempt(name string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
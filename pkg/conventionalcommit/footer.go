@@ -0,0 +1,15 @@
+package conventionalcommit
+
+import "strings"
+
+// FooterValues returns the values of the footers with the given token.
+// The token is compared case-insensitively.
+func (l *Cc) FooterValues(token string) []string {
+	values := make([]string, 0)
+	for _, note := range l.Notes() {
+		if strings.EqualFold(note.Token(), token) {
+			values = append(values, note.Value())
+		}
+	}
+	return values
+}
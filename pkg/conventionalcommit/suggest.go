@@ -0,0 +1,65 @@
+package conventionalcommit
+
+import "fmt"
+
+// SuggestHeader returns a header with the dominant type and scope of the commits (ex: feat(api): description).
+// The first type found wins in case of tie.
+func SuggestHeader(commits []*Cc, description string) (string, bool) {
+	if len(commits) == 0 {
+		return "", false
+	}
+
+	// Dominant type
+	types := make([]string, 0)
+	typesCount := make(map[string]int)
+	for _, c := range commits {
+		if _, ok := typesCount[c.Type()]; !ok {
+			types = append(types, c.Type())
+		}
+		typesCount[c.Type()]++
+	}
+	dominantType := types[0]
+	for _, t := range types {
+		if typesCount[t] > typesCount[dominantType] {
+			dominantType = t
+		}
+	}
+
+	// Dominant scope of the commits of the dominant type
+	var (
+		scopes         = make([]string, 0)
+		scopesCount    = make(map[string]int)
+		breakingChange = false
+	)
+	for _, c := range commits {
+		if c.Type() != dominantType {
+			continue
+		}
+		if c.IsBreakingChange() {
+			breakingChange = true
+		}
+		if c.Scope() == "" {
+			continue
+		}
+		if _, ok := scopesCount[c.Scope()]; !ok {
+			scopes = append(scopes, c.Scope())
+		}
+		scopesCount[c.Scope()]++
+	}
+
+	header := dominantType
+	if len(scopes) > 0 {
+		dominantScope := scopes[0]
+		for _, s := range scopes {
+			if scopesCount[s] > scopesCount[dominantScope] {
+				dominantScope = s
+			}
+		}
+		header += "(" + dominantScope + ")"
+	}
+	if breakingChange {
+		header += "!"
+	}
+
+	return fmt.Sprintf("%s: %s", header, description), true
+}
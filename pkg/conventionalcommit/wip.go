@@ -0,0 +1,19 @@
+package conventionalcommit
+
+import (
+	"regexp"
+	"strings"
+)
+
+// wipRe matches the work in progress prefixes of a title (ex: WIP, WIP:, [WIP]).
+var wipRe = regexp.MustCompile(`(?i)^\s*(?:\[wip\]|wip\b:?)\s*`)
+
+// IsWIP returns true if the title starts with a work in progress prefix.
+func IsWIP(title string) bool {
+	return wipRe.MatchString(title)
+}
+
+// TrimWIP removes the work in progress prefix of the title.
+func TrimWIP(title string) string {
+	return strings.TrimSpace(wipRe.ReplaceAllString(title, ""))
+}
@@ -0,0 +1,40 @@
+package conventionalissue
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/FrangipaneTeam/crown/pkg/common"
+)
+
+// titleRe matches the issue title format "[SCOPE] title".
+var titleRe = regexp.MustCompile(`^\[(?P<scope>[^\[\]]+)\]\s*(?P<subject>\S.*)$`)
+
+type Title struct {
+	scope   string
+	subject string
+}
+
+// ParseTitle parses an issue title and returns a conventional issue title.
+func ParseTitle(title string) (*Title, error) {
+	m := common.ReSubMatchMap(titleRe, strings.TrimSpace(title))
+	if len(m) == 0 || strings.TrimSpace(m["scope"]) == "" {
+		return nil, errors.New("invalid issue title format. Format is \"[SCOPE] title\"")
+	}
+
+	return &Title{
+		scope:   strings.TrimSpace(m["scope"]),
+		subject: strings.TrimSpace(m["subject"]),
+	}, nil
+}
+
+// Scope returns the scope of the issue title.
+func (t *Title) Scope() string {
+	return t.scope
+}
+
+// Subject returns the subject of the issue title.
+func (t *Title) Subject() string {
+	return t.subject
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"encoding/json"
+	"strings"
+
+	"go.etcd.io/bbolt"
+)
+
+// CommitVerdict is the result of the lint of a commit.
+type CommitVerdict struct {
+	// SHA is the SHA of the commit
+	SHA string
+	// ConfigHash is the version of the lint of the commit, the verdicts of a previous version are ignored
+	ConfigHash string
+	// Valid is true if the commit message is a conventional commit
+	Valid bool
+	// LabelsType is the list of type labels of the commit
+	LabelsType []string
+	// LabelsCategory is the list of category labels of the commit
+	LabelsCategory []string
+}
+
+type CommitDB struct {
+	Name
+}
+
+// CommitDBNew returns a new CommitDB.
+func CommitDBNew() *CommitDB {
+	return &CommitDB{DBCommit}
+}
+
+// commitKey returns the key of the commit verdict (ex: 1/owner/repo/12/sha).
+func commitKey(prKey, sha string) []byte {
+	return []byte(prKey + "/" + sha)
+}
+
+// GetVerdict returns the verdict of the commit, nil if the commit was never linted.
+func (db *CommitDB) GetVerdict(prKey, sha string) (*CommitVerdict, error) {
+	var v *CommitVerdict
+	err := DataBase.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(db.Name))
+		x := b.Get(commitKey(prKey, sha))
+		if x == nil {
+			return nil
+		}
+		v = &CommitVerdict{}
+		return json.Unmarshal(x, v)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// SetVerdict records the verdict of the commit.
+func (db *CommitDB) SetVerdict(prKey string, v CommitVerdict) error {
+	x, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return DataBase.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(db.Name))
+		return b.Put(commitKey(prKey, v.SHA), x)
+	})
+}
+
+// DeleteVerdicts deletes the verdicts of all the commits of the pull request.
+func (db *CommitDB) DeleteVerdicts(prKey string) error {
+	prefix := []byte(prKey + "/")
+	return DataBase.Update(func(tx *bbolt.Tx) error {
+		c := tx.Bucket([]byte(db.Name)).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && strings.HasPrefix(string(k), string(prefix)); k, _ = c.Seek(prefix) {
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -11,13 +11,17 @@ var DataBase *DB
 type Name string
 
 const (
-	DBTrack Name = "Track"
-	DBEvent Name = "Event"
+	DBTrack  Name = "Track"
+	DBEvent  Name = "Event"
+	DBMerged Name = "Merged"
+	DBCommit Name = "Commit"
 )
 
 var DBNames = []Name{
 	DBTrack,
 	DBEvent,
+	DBMerged,
+	DBCommit,
 }
 
 type DB struct {
@@ -3,6 +3,7 @@ package db
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"go.etcd.io/bbolt"
 )
@@ -20,6 +21,102 @@ type Event struct {
 	LabelsCategory []string
 	// LabelsType is the list of labels
 	LabelsType []string
+	// DCOOverrideBy is the login of the maintainer who overrode the DCO check
+	DCOOverrideBy string `json:",omitempty"`
+	// DCOOverrideHeadSHA is the head of the pull request when the DCO check was overridden, a new head ends the override
+	DCOOverrideHeadSHA string `json:",omitempty"`
+	// LabelsAddedByUser is the list of labels added by a human, they are never removed by crown
+	LabelsAddedByUser []string `json:",omitempty"`
+	// LabelsRemovedByUser is the list of labels removed by a human, they are never added back by crown
+	LabelsRemovedByUser []string `json:",omitempty"`
+	// HoldBy is the login of the user who put the pull request on hold
+	HoldBy string `json:",omitempty"`
+	// HoldReason is the reason of the hold
+	HoldReason string `json:",omitempty"`
+	// LGTMBy is the login of the reviewer who ran /lgtm
+	LGTMBy string `json:",omitempty"`
+	// LGTMReviewID is the id of the review created by /lgtm
+	LGTMReviewID int64 `json:",omitempty"`
+	// LGTMHeadSHA is the head of the pull request when /lgtm was run, a new head resets the lgtm
+	LGTMHeadSHA string `json:",omitempty"`
+	// ApprovedBy is the login of the owner who ran /approve
+	ApprovedBy string `json:",omitempty"`
+	// ApprovedHeadSHA is the head of the pull request when the approval was last checked
+	ApprovedHeadSHA string `json:",omitempty"`
+	// CherryPicks are the cherry-picks to run once the pull request is merged, the key is the target branch and the value the requester
+	CherryPicks map[string]string `json:",omitempty"`
+	// ArchivedAt is the date when the pull request was closed without merge, zero while the pull request is open
+	ArchivedAt time.Time
+}
+
+// IsArchived returns true if the pull request was closed without merge.
+func (e *Event) IsArchived() bool {
+	return !e.ArchivedAt.IsZero()
+}
+
+// Archive marks the record of a pull request closed without merge.
+func (e *Event) Archive() {
+	e.ArchivedAt = time.Now()
+}
+
+// Activate marks the record of a reopened pull request.
+func (e *Event) Activate() {
+	e.ArchivedAt = time.Time{}
+}
+
+// IsPurgeable checks if the retention of the archived record is over.
+func (e *Event) IsPurgeable(retention time.Duration) bool {
+	return e.IsArchived() && time.Since(e.ArchivedAt) > retention
+}
+
+// IsHold returns true if the pull request is on hold.
+func (e *Event) IsHold() bool {
+	return e.HoldBy != ""
+}
+
+// UserLabeled records a label added by a human.
+func (e *Event) UserLabeled(label string) {
+	e.LabelsRemovedByUser = removeString(e.LabelsRemovedByUser, label)
+	if !containsString(e.LabelsAddedByUser, label) {
+		e.LabelsAddedByUser = append(e.LabelsAddedByUser, label)
+	}
+}
+
+// UserUnlabeled records a label removed by a human.
+func (e *Event) UserUnlabeled(label string) {
+	e.LabelsAddedByUser = removeString(e.LabelsAddedByUser, label)
+	if !containsString(e.LabelsRemovedByUser, label) {
+		e.LabelsRemovedByUser = append(e.LabelsRemovedByUser, label)
+	}
+}
+
+// IsLabelAddedByUser returns true if the label was added by a human.
+func (e *Event) IsLabelAddedByUser(label string) bool {
+	return containsString(e.LabelsAddedByUser, label)
+}
+
+// IsLabelRemovedByUser returns true if the label was removed by a human.
+func (e *Event) IsLabelRemovedByUser(label string) bool {
+	return containsString(e.LabelsRemovedByUser, label)
+}
+
+func containsString(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(list []string, s string) []string {
+	x := make([]string, 0, len(list))
+	for _, v := range list {
+		if v != s {
+			x = append(x, v)
+		}
+	}
+	return x
 }
 
 // GetKey returns the key of the event.
@@ -79,6 +176,38 @@ func (db *EventDB) DeleteEvent(key string) error {
 	})
 }
 
+// PurgeArchived deletes the archived records whose retention is over and the verdicts of their commits.
+// It returns the keys of the purged records.
+func (db *EventDB) PurgeArchived(retention time.Duration) ([]string, error) {
+	var keys []string
+	err := DataBase.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(db.Name))
+		return b.ForEach(func(k, v []byte) error {
+			var event Event
+			if err := json.Unmarshal(v, &event); err != nil {
+				return err
+			}
+			if event.IsPurgeable(retention) {
+				keys = append(keys, string(k))
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		if err := db.DeleteEvent(key); err != nil {
+			return nil, err
+		}
+		if err := CommitDBNew().DeleteVerdicts(key); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
 // GetEvents returns all events from the database.
 func (db *EventDB) GetEvents() ([]Event, error) {
 	var events []Event
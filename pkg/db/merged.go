@@ -0,0 +1,85 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// Merged is the final state of a merged pull request.
+// It's used by the changelog and statistics features.
+type Merged struct {
+	// InstallationID is the id of the installation
+	InstallationID int64
+	// RepoOwner is the owner of the repository
+	RepoOwner string
+	// RepoName is the name of the repository
+	RepoName string
+	// ID is the number of the pull request
+	ID int
+	// Title is the title of the pull request
+	Title string
+	// Type is the conventional type of the title (ex: feat)
+	Type string
+	// Scope is the conventional scope of the title
+	Scope string
+	// BreakingChange is true if the title is a breaking change
+	BreakingChange bool
+	// Size is the size of the pull request (ex: XS)
+	Size string
+	// Author is the login of the author
+	Author string
+	// MergedAt is the date of the merge
+	MergedAt time.Time
+}
+
+// GetKey returns the key of the merged pull request.
+func (m *Merged) GetKey() string {
+	return fmt.Sprintf("%d/%s/%s/%d", m.InstallationID, m.RepoOwner, m.RepoName, m.ID)
+}
+
+type MergedDB struct {
+	Name
+}
+
+// MergedDBNew returns a new MergedDB.
+func MergedDBNew() *MergedDB {
+	return &MergedDB{DBMerged}
+}
+
+// AddMerged add a merged pull request to the database.
+func (db *MergedDB) AddMerged(m Merged) error {
+	x, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	return DataBase.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(db.Name))
+		return b.Put([]byte(m.GetKey()), x)
+	})
+}
+
+// GetMergedForRepository returns the merged pull requests of the repository.
+func (db *MergedDB) GetMergedForRepository(installationID int64, owner, repo string) ([]Merged, error) {
+	var merged []Merged
+	err := DataBase.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(db.Name))
+		return b.ForEach(func(k, v []byte) error {
+			var m Merged
+			if err := json.Unmarshal(v, &m); err != nil {
+				return err
+			}
+			if m.InstallationID == installationID && m.RepoOwner == owner && m.RepoName == repo {
+				merged = append(merged, m)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
@@ -0,0 +1,27 @@
+package db
+
+import (
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	intervalLoopPurge = 1 * time.Hour
+)
+
+// WatchArchived purges the records of the pull requests closed without merge
+// once the retention is over.
+func WatchArchived(logger zerolog.Logger, retention time.Duration) {
+	for {
+		keys, err := EventDBNew(DBEvent).PurgeArchived(retention)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while purging archived events")
+		}
+		for _, key := range keys {
+			logger.Debug().Msgf("Purge archived event %s", key)
+		}
+
+		time.Sleep(intervalLoopPurge)
+	}
+}
@@ -0,0 +1,71 @@
+package dco
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/FrangipaneTeam/crown/pkg/conventionalcommit"
+)
+
+// Developer Certificate of Origin
+// More details : https://developercertificate.org/
+
+// Trailer is the trailer of the sign-off.
+const Trailer = "Signed-off-by"
+
+var (
+	// signatureRe matches the value of the sign-off trailer (ex: John Doe <john@doe.com>).
+	signatureRe = regexp.MustCompile(`^\s*(?P<name>.+?)\s*<(?P<email>[^>]+)>\s*$`)
+	// trailerRe matches the sign-off trailers of a commit message.
+	trailerRe = regexp.MustCompile(`(?mi)^Signed-off-by:\s*(.+)$`)
+)
+
+type Signature struct {
+	Name  string
+	Email string
+}
+
+// Matches returns true if the signature matches the name and the email.
+func (s Signature) Matches(name, email string) bool {
+	return strings.EqualFold(strings.TrimSpace(s.Email), strings.TrimSpace(email)) && strings.TrimSpace(s.Name) == strings.TrimSpace(name)
+}
+
+// Signatures returns the sign-offs of the commit message.
+// The footers of the conventional commit are used if cc is not nil,
+// otherwise the trailers are searched in the message.
+func Signatures(msg string, cc *conventionalcommit.Cc) []Signature {
+	values := make([]string, 0)
+	if cc != nil {
+		values = cc.FooterValues(Trailer)
+	} else {
+		for _, m := range trailerRe.FindAllStringSubmatch(msg, -1) {
+			values = append(values, m[1])
+		}
+	}
+
+	signatures := make([]Signature, 0)
+	for _, v := range values {
+		if m := signatureRe.FindStringSubmatch(v); m != nil {
+			signatures = append(signatures, Signature{Name: m[1], Email: m[2]})
+		}
+	}
+	return signatures
+}
+
+// IsMergeCommit returns true if the commit has more than one parent.
+func IsMergeCommit(commit *github.RepositoryCommit) bool {
+	return len(commit.Parents) > 1
+}
+
+// IsSigned returns true if the commit has a sign-off matching its author.
+func IsSigned(commit *github.RepositoryCommit, cc *conventionalcommit.Cc) bool {
+	author := commit.GetCommit().GetAuthor()
+	for _, s := range Signatures(commit.GetCommit().GetMessage(), cc) {
+		if s.Matches(author.GetName(), author.GetEmail()) {
+			return true
+		}
+	}
+	return false
+}
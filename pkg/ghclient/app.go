@@ -0,0 +1,43 @@
+package ghclient
+
+import (
+	"strings"
+	"sync"
+)
+
+var (
+	// appLogin is the login of the app (ex: crown[bot]), it's fetched once
+	appLogin   string
+	appLoginMu sync.Mutex
+)
+
+// GetAppLogin returns the login of the app (ex: crown[bot]).
+func (g *GHClient) GetAppLogin() (string, error) {
+	appLoginMu.Lock()
+	defer appLoginMu.Unlock()
+
+	if appLogin == "" {
+		client, err := g.githubapp.NewAppClient()
+		if err != nil {
+			return "", err
+		}
+
+		app, _, err := client.Apps.Get(g.context, "")
+		if err != nil {
+			return "", err
+		}
+		appLogin = app.GetSlug() + "[bot]"
+	}
+
+	return appLogin, nil
+}
+
+// IsApp returns true if the login is the login of the app.
+func (g *GHClient) IsApp(login string) (bool, error) {
+	x, err := g.GetAppLogin()
+	if err != nil {
+		return false, err
+	}
+
+	return strings.EqualFold(x, login), nil
+}
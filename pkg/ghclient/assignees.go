@@ -0,0 +1,60 @@
+package ghclient
+
+import (
+	"net/http"
+
+	"github.com/google/go-github/v47/github"
+)
+
+// AddAssignees adds the assignees to the issue.
+func (g *GHClient) AddAssignees(assignees []string) error {
+	_, _, err := g.client.Issues.AddAssignees(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), assignees)
+	return err
+}
+
+// RemoveAssignees removes the assignees from the issue.
+func (g *GHClient) RemoveAssignees(assignees []string) error {
+	_, _, err := g.client.Issues.RemoveAssignees(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), assignees)
+	return err
+}
+
+// RequestReviewers requests a review from the users and the teams (slugs) on the pull request.
+func (g *GHClient) RequestReviewers(users, teams []string) error {
+	_, _, err := g.client.PullRequests.RequestReviewers(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), github.ReviewersRequest{
+		Reviewers:     users,
+		TeamReviewers: teams,
+	})
+	return err
+}
+
+// RemoveReviewers removes the review requests of the users and the teams (slugs) on the pull request.
+func (g *GHClient) RemoveReviewers(users, teams []string) error {
+	_, err := g.client.PullRequests.RemoveReviewers(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), github.ReviewersRequest{
+		Reviewers:     users,
+		TeamReviewers: teams,
+	})
+	return err
+}
+
+// IsCollaborator returns true if the user is a collaborator of the repository.
+func (g *GHClient) IsCollaborator(user string) (bool, error) {
+	isCollaborator, _, err := g.client.Repositories.IsCollaborator(g.context, g.repoOwner, g.repoName, user)
+	if err != nil {
+		return false, err
+	}
+
+	return isCollaborator, nil
+}
+
+// IsTeamRepository returns true if the team has access to the repository.
+func (g *GHClient) IsTeamRepository(org, teamSlug string) (bool, error) {
+	_, resp, err := g.client.Teams.IsTeamRepoBySlug(g.context, org, teamSlug, g.repoOwner, g.repoName)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
@@ -4,24 +4,49 @@ import "github.com/google/go-github/v47/github"
 
 // CreateComment creates a comment on the issue.
 func (g *GHClient) CreateComment(comment github.IssueComment) error {
-	_, _, err := g.client.Issues.CreateComment(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), &comment)
+	c, _, err := g.client.Issues.CreateComment(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), &comment)
 	if err != nil {
 		return err
 	}
 
+	if g.comments != nil {
+		g.comments = append(g.comments, c)
+	}
+
 	return nil
 }
 
 // ListComments lists all comments on the issue.
+// The comments are listed once and kept up to date by CreateComment, EditComment and DeleteComment.
 func (g *GHClient) ListComments() ([]*github.IssueComment, error) {
-	comments, _, err := g.client.Issues.ListComments(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), nil)
-	if err != nil {
-		return nil, err
+	if g.comments != nil {
+		return g.comments, nil
+	}
+
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	comments := make([]*github.IssueComment, 0)
+	for {
+		c, resp, err := g.client.Issues.ListComments(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), opts)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, c...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
 	}
 
+	g.comments = comments
 	return comments, nil
 }
 
+// InvalidateComments clears the cache of the comments.
+func (g *GHClient) InvalidateComments() {
+	g.comments = nil
+}
+
 // DeleteComment deletes a comment on the issue.
 func (g *GHClient) DeleteComment(commentID int64) error {
 	_, err := g.client.Issues.DeleteComment(g.context, g.repoOwner, g.repoName, commentID)
@@ -29,15 +54,31 @@ func (g *GHClient) DeleteComment(commentID int64) error {
 		return err
 	}
 
+	if g.comments != nil {
+		comments := make([]*github.IssueComment, 0, len(g.comments))
+		for _, c := range g.comments {
+			if c.GetID() != commentID {
+				comments = append(comments, c)
+			}
+		}
+		g.comments = comments
+	}
+
 	return nil
 }
 
 // EditComment edits a comment on the issue.
 func (g *GHClient) EditComment(commentID int64, comment github.IssueComment) error {
-	_, _, err := g.client.Issues.EditComment(g.context, g.repoOwner, g.repoName, commentID, &comment)
+	c, _, err := g.client.Issues.EditComment(g.context, g.repoOwner, g.repoName, commentID, &comment)
 	if err != nil {
 		return err
 	}
 
+	for i, x := range g.comments {
+		if x.GetID() == commentID {
+			g.comments[i] = c
+		}
+	}
+
 	return nil
 }
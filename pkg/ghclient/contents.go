@@ -0,0 +1,25 @@
+package ghclient
+
+import (
+	"github.com/google/go-github/v47/github"
+)
+
+// GetFileContent returns the content of the file on the default branch.
+func (g *GHClient) GetFileContent(path string) (string, error) {
+	file, _, _, err := g.client.Repositories.GetContents(g.context, g.repoOwner, g.repoName, path, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return file.GetContent()
+}
+
+// ListDirectory returns the content of the directory on the default branch.
+func (g *GHClient) ListDirectory(path string) ([]*github.RepositoryContent, error) {
+	_, dir, _, err := g.client.Repositories.GetContents(g.context, g.repoOwner, g.repoName, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return dir, nil
+}
@@ -3,6 +3,7 @@ package ghclient
 import (
 	"context"
 	"errors"
+	"net/http"
 
 	"github.com/google/go-github/v47/github"
 	"github.com/palantir/go-githubapp/githubapp"
@@ -21,6 +22,8 @@ type GHClient struct {
 
 	issue       *github.Issue
 	pullRequest *github.PullRequest
+	// comments is the cache of the issue comments, it's listed once per event
+	comments []*github.IssueComment
 
 	repoOwner    string
 	repoName     string
@@ -147,6 +150,35 @@ func (g *GHClient) GetInstallationID() int64 {
 	return g.installationID
 }
 
+// GetPermissionLevel returns the permission level of the user on the repository.
+// The role name (admin, maintain, write, triage, read) is returned when available,
+// otherwise the permission (admin, write, read, none).
+func (g *GHClient) GetPermissionLevel(user string) (string, error) {
+	perm, _, err := g.client.Repositories.GetPermissionLevel(g.context, g.repoOwner, g.repoName, user)
+	if err != nil {
+		return "", err
+	}
+
+	if perm.GetUser().GetRoleName() != "" {
+		return perm.GetUser().GetRoleName(), nil
+	}
+
+	return perm.GetPermission(), nil
+}
+
+// IsTeamMember returns true if the user is an active member of the team.
+func (g *GHClient) IsTeamMember(org, teamSlug, user string) (bool, error) {
+	m, resp, err := g.client.Teams.GetTeamMembershipBySlug(g.context, org, teamSlug, user)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return m.GetState() == "active", nil
+}
+
 // IsInOrganization returns true if the user is in the organization.
 func (g *GHClient) IsInOrganization(user string) (bool, error) {
 	inOrg, _, err := g.client.Organizations.IsMember(g.context, g.GetOrg(), user)
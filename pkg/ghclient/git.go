@@ -0,0 +1,104 @@
+package ghclient
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/go-github/v47/github"
+)
+
+// ErrMergeConflict is returned by Merge when the head does not merge cleanly into the base.
+var ErrMergeConflict = errors.New("merge conflict")
+
+// GetBranch returns the branch of the repository, nil if it does not exist.
+func (g *GHClient) GetBranch(branch string) (*github.Branch, error) {
+	b, resp, err := g.client.Repositories.GetBranch(g.context, g.repoOwner, g.repoName, branch, true)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// GetGitCommit returns the git commit of the repository.
+func (g *GHClient) GetGitCommit(sha string) (*github.Commit, error) {
+	c, _, err := g.client.Git.GetCommit(g.context, g.repoOwner, g.repoName, sha)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// CreateGitCommit creates a git commit, the commit is not referenced by a branch.
+func (g *GHClient) CreateGitCommit(commit *github.Commit) (*github.Commit, error) {
+	c, _, err := g.client.Git.CreateCommit(g.context, g.repoOwner, g.repoName, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// CreateBranch creates the branch on the commit.
+func (g *GHClient) CreateBranch(branch, sha string) error {
+	_, _, err := g.client.Git.CreateRef(g.context, g.repoOwner, g.repoName, &github.Reference{
+		Ref:    github.String("refs/heads/" + branch),
+		Object: &github.GitObject{SHA: github.String(sha)},
+	})
+	return err
+}
+
+// UpdateBranch moves the branch to the commit, the update is forced.
+func (g *GHClient) UpdateBranch(branch, sha string) error {
+	_, _, err := g.client.Git.UpdateRef(g.context, g.repoOwner, g.repoName, &github.Reference{
+		Ref:    github.String("refs/heads/" + branch),
+		Object: &github.GitObject{SHA: github.String(sha)},
+	}, true)
+	return err
+}
+
+// DeleteBranch deletes the branch.
+func (g *GHClient) DeleteBranch(branch string) error {
+	_, err := g.client.Git.DeleteRef(g.context, g.repoOwner, g.repoName, "refs/heads/"+branch)
+	return err
+}
+
+// Merge merges the head (branch or commit) into the base branch.
+// The returned commit is nil if there is nothing to merge.
+func (g *GHClient) Merge(base, head, message string) (*github.RepositoryCommit, error) {
+	c, resp, err := g.client.Repositories.Merge(g.context, g.repoOwner, g.repoName, &github.RepositoryMergeRequest{
+		Base:          github.String(base),
+		Head:          github.String(head),
+		CommitMessage: github.String(message),
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			return nil, ErrMergeConflict
+		}
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+
+	return c, nil
+}
+
+// CreatePullRequest opens a pull request from the head branch to the base branch.
+func (g *GHClient) CreatePullRequest(title, head, base, body string) (*github.PullRequest, error) {
+	pr, _, err := g.client.PullRequests.Create(g.context, g.repoOwner, g.repoName, &github.NewPullRequest{
+		Title: github.String(title),
+		Head:  github.String(head),
+		Base:  github.String(base),
+		Body:  github.String(body),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
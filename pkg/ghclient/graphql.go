@@ -0,0 +1,27 @@
+package ghclient
+
+import (
+	"github.com/shurcooL/githubv4"
+)
+
+// MinimizeComment hides a comment with the classifier (ex: OUTDATED).
+// The REST API does not support it, the GraphQL API is used.
+func (g *GHClient) MinimizeComment(nodeID string, classifier githubv4.ReportedContentClassifiers) error {
+	client, err := g.githubapp.NewInstallationV4Client(g.installationID)
+	if err != nil {
+		return err
+	}
+
+	var m struct {
+		MinimizeComment struct {
+			MinimizedComment struct {
+				IsMinimized bool
+			}
+		} `graphql:"minimizeComment(input: $input)"`
+	}
+
+	return client.Mutate(g.context, &m, githubv4.MinimizeCommentInput{
+		SubjectID:  githubv4.ID(nodeID),
+		Classifier: classifier,
+	}, nil)
+}
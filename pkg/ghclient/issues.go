@@ -0,0 +1,91 @@
+package ghclient
+
+import (
+	"strings"
+
+	"github.com/google/go-github/v47/github"
+)
+
+// GetIssueByNumber returns the issue of the repository.
+func (g *GHClient) GetIssueByNumber(owner, repo string, number int) (*github.Issue, error) {
+	issue, _, err := g.client.Issues.Get(g.context, owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+
+	return issue, nil
+}
+
+// CloseIssue closes the issue or the pull request.
+// The reason (completed or not_planned) is ignored by the pull requests, it is not sent if empty.
+func (g *GHClient) CloseIssue(reason string) error {
+	req := &github.IssueRequest{
+		State: github.String("closed"),
+	}
+	if reason != "" {
+		req.StateReason = github.String(reason)
+	}
+
+	_, _, err := g.client.Issues.Edit(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), req)
+	return err
+}
+
+// ReopenIssue reopens the issue or the pull request.
+func (g *GHClient) ReopenIssue() error {
+	_, _, err := g.client.Issues.Edit(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), &github.IssueRequest{
+		State: github.String("open"),
+	})
+	return err
+}
+
+// LockIssue locks the conversation of the issue or the pull request.
+// The reason (off-topic, too heated, resolved or spam) is not sent if empty.
+func (g *GHClient) LockIssue(reason string) error {
+	opts := &github.LockIssueOptions{}
+	if reason != "" {
+		opts.LockReason = reason
+	}
+
+	_, err := g.client.Issues.Lock(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), opts)
+	return err
+}
+
+// FindMilestone returns the open milestone of the repository with the title (case insensitive).
+func (g *GHClient) FindMilestone(title string) (*github.Milestone, error) {
+	opts := &github.MilestoneListOptions{
+		State:       "open",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		milestones, resp, err := g.client.Issues.ListMilestones(g.context, g.repoOwner, g.repoName, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range milestones {
+			if strings.EqualFold(m.GetTitle(), title) {
+				return m, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// SetMilestone sets the milestone of the issue or the pull request.
+func (g *GHClient) SetMilestone(number int) error {
+	_, _, err := g.client.Issues.Edit(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), &github.IssueRequest{
+		Milestone: github.Int(number),
+	})
+	return err
+}
+
+// RemoveMilestone removes the milestone of the issue or the pull request.
+func (g *GHClient) RemoveMilestone() error {
+	_, _, err := g.client.Issues.RemoveMilestone(g.context, g.repoOwner, g.repoName, g.GetIssueNumber())
+	return err
+}
@@ -4,10 +4,92 @@ import "github.com/google/go-github/v47/github"
 
 // GetCommits returns the commits of the pull request.
 func (g *GHClient) GetCommits() ([]*github.RepositoryCommit, error) {
-	commits, _, err := g.client.PullRequests.ListCommits(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), nil)
+	opts := &github.ListOptions{PerPage: 100}
+	commits := make([]*github.RepositoryCommit, 0)
+	for {
+		c, resp, err := g.client.PullRequests.ListCommits(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), opts)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, c...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return commits, nil
+}
+
+// FetchPullRequest fetches the pull request of the issue.
+// It's used for the events which contain only the issue (ex: issue_comment).
+func (g *GHClient) FetchPullRequest() (*github.PullRequest, error) {
+	pr, _, err := g.client.PullRequests.Get(g.context, g.repoOwner, g.repoName, g.GetIssueNumber())
 	if err != nil {
 		return nil, err
 	}
 
-	return commits, nil
+	g.pullRequest = pr
+	return pr, nil
+}
+
+// ListFiles returns the paths of the files changed by the pull request.
+func (g *GHClient) ListFiles() ([]string, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	files := make([]string, 0)
+	for {
+		f, resp, err := g.client.PullRequests.ListFiles(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range f {
+			files = append(files, file.GetFilename())
+			// A renamed file is also owned by the owners of its previous path
+			if file.GetPreviousFilename() != "" {
+				files = append(files, file.GetPreviousFilename())
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return files, nil
+}
+
+// EditPullRequestTitle edits the title of the pull request.
+func (g *GHClient) EditPullRequestTitle(title string) error {
+	pr, _, err := g.client.PullRequests.Edit(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), &github.PullRequest{
+		Title: github.String(title),
+	})
+	if err != nil {
+		return err
+	}
+
+	g.pullRequest = pr
+	return nil
+}
+
+// CreateReview creates a review on the pull request (APPROVE, REQUEST_CHANGES or COMMENT) and returns its id.
+func (g *GHClient) CreateReview(event, body string) (int64, error) {
+	review, _, err := g.client.PullRequests.CreateReview(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), &github.PullRequestReviewRequest{
+		Event: github.String(event),
+		Body:  github.String(body),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return review.GetID(), nil
+}
+
+// DismissReview dismisses the review of the pull request.
+func (g *GHClient) DismissReview(reviewID int64, message string) error {
+	_, _, err := g.client.PullRequests.DismissReview(g.context, g.repoOwner, g.repoName, g.GetIssueNumber(), reviewID, &github.PullRequestReviewDismissalRequest{
+		Message: github.String(message),
+	})
+	return err
 }
@@ -0,0 +1,233 @@
+package issueform
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v47/github"
+	"gopkg.in/yaml.v3"
+
+	"github.com/FrangipaneTeam/crown/pkg/ghclient"
+	"github.com/FrangipaneTeam/crown/pkg/markdown"
+)
+
+// Issue forms syntax
+// More details : https://docs.github.com/en/communities/using-templates-to-encourage-useful-issues-and-pull-requests/syntax-for-issue-forms
+
+const (
+	// Directory is the directory of the issue forms in the repository.
+	Directory = ".github/ISSUE_TEMPLATE"
+	// ConfigFile is the configuration file of the template chooser, it's not an issue form.
+	ConfigFile = "config.yml"
+
+	typeMarkdown   = "markdown"
+	typeCheckboxes = "checkboxes"
+)
+
+type Form struct {
+	// File is the name of the file of the issue form
+	File        string  `yaml:"-"`
+	Name        string  `yaml:"name"`
+	Description string  `yaml:"description"`
+	Title       string  `yaml:"title"`
+	Labels      Labels  `yaml:"labels"`
+	Body        []Field `yaml:"body"`
+}
+
+type Field struct {
+	Type        string      `yaml:"type"`
+	ID          string      `yaml:"id"`
+	Attributes  Attributes  `yaml:"attributes"`
+	Validations Validations `yaml:"validations"`
+}
+
+type Attributes struct {
+	Label   string   `yaml:"label"`
+	Options []Option `yaml:"options"`
+}
+
+type Validations struct {
+	Required bool `yaml:"required"`
+}
+
+type Option struct {
+	Label    string `yaml:"label"`
+	Required bool   `yaml:"required"`
+}
+
+// Labels is the list of labels of the issue form.
+// It can be written as a list or as a comma-separated string.
+type Labels []string
+
+// UnmarshalYAML decodes a list or a comma-separated string of labels.
+func (l *Labels) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = make(Labels, 0)
+		for _, label := range strings.Split(value.Value, ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				*l = append(*l, label)
+			}
+		}
+		return nil
+	}
+
+	var labels []string
+	if err := value.Decode(&labels); err != nil {
+		return err
+	}
+	*l = labels
+	return nil
+}
+
+// UnmarshalYAML decodes a dropdown option (string) or a checkboxes option (mapping).
+func (o *Option) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		o.Label = value.Value
+		return nil
+	}
+
+	type option Option
+	var x option
+	if err := value.Decode(&x); err != nil {
+		return err
+	}
+	*o = Option(x)
+	return nil
+}
+
+// Parse parses an issue form.
+func Parse(file string, data []byte) (*Form, error) {
+	f := &Form{}
+	if err := yaml.Unmarshal(data, f); err != nil {
+		return nil, err
+	}
+
+	if f.Name == "" || len(f.Body) == 0 {
+		return nil, fmt.Errorf("%s is not an issue form", file)
+	}
+
+	f.File = file
+	return f, nil
+}
+
+// Fields returns the fields rendered as a section in the issue body.
+func (f *Form) Fields() []Field {
+	fields := make([]Field, 0)
+	for _, field := range f.Body {
+		if field.Type != typeMarkdown && field.Attributes.Label != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Score returns the number of fields of the form found in the issue body.
+func (f *Form) Score(body string) int {
+	sections := markdown.Sections(body)
+
+	score := 0
+	for _, field := range f.Fields() {
+		if _, ok := markdown.FindSection(sections, field.Attributes.Label); ok {
+			score++
+		}
+	}
+	return score
+}
+
+// MissingFields returns the required fields which are not filled in the issue body.
+// For checkboxes, the required options which are not checked are returned.
+func (f *Form) MissingFields(body string) []string {
+	sections := markdown.Sections(body)
+	missing := make([]string, 0)
+
+	for _, field := range f.Fields() {
+		section, found := markdown.FindSection(sections, field.Attributes.Label)
+
+		if field.Type == typeCheckboxes {
+			tasks := make([]markdown.Task, 0)
+			if found {
+				tasks = markdown.Tasks(section.Content)
+			}
+			for _, option := range field.Attributes.Options {
+				if option.Required && !isChecked(tasks, option.Label) {
+					missing = append(missing, fmt.Sprintf("%s: %s", field.Attributes.Label, option.Label))
+				}
+			}
+			continue
+		}
+
+		if field.Validations.Required && (!found || markdown.IsEmpty(section.Content)) {
+			missing = append(missing, field.Attributes.Label)
+		}
+	}
+
+	return missing
+}
+
+// isChecked returns true if the task with the given text is checked.
+func isChecked(tasks []markdown.Task, text string) bool {
+	for _, t := range tasks {
+		if t.Text == strings.TrimSpace(text) {
+			return t.Checked
+		}
+	}
+	return false
+}
+
+// Detect returns the form used to write the issue body.
+// The form with the most fields found in the body is returned, nil if no field is found.
+func Detect(forms []*Form, body string) *Form {
+	var (
+		best      *Form
+		bestScore int
+	)
+
+	for _, f := range forms {
+		if score := f.Score(body); score > bestScore {
+			best = f
+			bestScore = score
+		}
+	}
+
+	return best
+}
+
+// Load returns the issue forms of the repository.
+// If the repository has no issue forms, an empty list is returned.
+func Load(ghc *ghclient.GHClient) ([]*Form, error) {
+	forms := make([]*Form, 0)
+
+	files, err := ghc.ListDirectory(Directory)
+	if err != nil {
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound {
+			return forms, nil
+		}
+		return nil, err
+	}
+
+	for _, file := range files {
+		if file.GetType() != "file" || file.GetName() == ConfigFile {
+			continue
+		}
+		if !strings.HasSuffix(file.GetName(), ".yml") && !strings.HasSuffix(file.GetName(), ".yaml") {
+			continue
+		}
+
+		content, err := ghc.GetFileContent(file.GetPath())
+		if err != nil {
+			return nil, err
+		}
+
+		f, err := Parse(file.GetName(), []byte(content))
+		if err != nil {
+			ghc.Logger.Debug().Err(err).Msgf("Ignoring issue template %s", file.GetPath())
+			continue
+		}
+		forms = append(forms, f)
+	}
+
+	return forms, nil
+}
@@ -0,0 +1,95 @@
+package issueref
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/FrangipaneTeam/crown/pkg/conventionalcommit"
+)
+
+// Linking a pull request to an issue
+// More details : https://docs.github.com/en/issues/tracking-your-work-with-issues/linking-a-pull-request-to-an-issue
+
+var (
+	// closingRe matches the closing keywords followed by an issue reference (ex: Closes #12, Fixes org/repo#3).
+	closingRe = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?)\s*:?\s+(?:([\w.-]+)/([\w.-]+))?#(\d+)\b`)
+	// refRe matches an issue reference in a commit footer value (ex: 12, #12, org/repo#3).
+	refRe = regexp.MustCompile(`^(?:([\w.-]+)/([\w.-]+))?#?(\d+)$`)
+
+	// FooterTokens is the list of the commit footer tokens which reference an issue.
+	FooterTokens = []string{
+		"Close", "Closes", "Closed",
+		"Fix", "Fixes", "Fixed",
+		"Resolve", "Resolves", "Resolved",
+		"Ref", "Refs",
+	}
+)
+
+type Ref struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// String returns the reference in the form owner/repo#number or #number.
+func (r Ref) String() string {
+	if r.Owner == "" {
+		return fmt.Sprintf("#%d", r.Number)
+	}
+	return fmt.Sprintf("%s/%s#%d", r.Owner, r.Repo, r.Number)
+}
+
+// Resolve returns the reference with the repository set if the reference is local.
+func (r Ref) Resolve(owner, repo string) Ref {
+	if r.Owner == "" {
+		r.Owner = owner
+		r.Repo = repo
+	}
+	return r
+}
+
+// FromText returns the issues referenced with a closing keyword in the text.
+func FromText(text string) []Ref {
+	refs := make([]Ref, 0)
+	for _, m := range closingRe.FindAllStringSubmatch(text, -1) {
+		number, err := strconv.Atoi(m[3])
+		if err != nil {
+			continue
+		}
+		refs = appendRef(refs, Ref{Owner: m[1], Repo: m[2], Number: number})
+	}
+	return refs
+}
+
+// FromFooters returns the issues referenced in the footers of the commit (ex: Refs: #12, Closes org/repo#3).
+func FromFooters(cc *conventionalcommit.Cc) []Ref {
+	refs := make([]Ref, 0)
+	for _, token := range FooterTokens {
+		for _, value := range cc.FooterValues(token) {
+			for _, v := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+				m := refRe.FindStringSubmatch(v)
+				if m == nil {
+					continue
+				}
+				number, err := strconv.Atoi(m[3])
+				if err != nil {
+					continue
+				}
+				refs = appendRef(refs, Ref{Owner: m[1], Repo: m[2], Number: number})
+			}
+		}
+	}
+	return refs
+}
+
+// appendRef appends the reference if it's not already in the list.
+func appendRef(refs []Ref, ref Ref) []Ref {
+	for _, r := range refs {
+		if r == ref {
+			return refs
+		}
+	}
+	return append(refs, ref)
+}
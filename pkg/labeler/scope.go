@@ -33,6 +33,11 @@ func LabelScope(scope string) *LabelerScope {
 	}
 }
 
+// IsLabelScope returns true if the label is in the form of "category/<scope>".
+func IsLabelScope(label string) bool {
+	return strings.HasPrefix(label, prefixScope+"/")
+}
+
 // FormatedLabelScope returns the label in the form of "category/<scope>".
 func formatedLabelScope(scope string) string {
 	return fmt.Sprintf("%s/%s", prefixScope, scope)
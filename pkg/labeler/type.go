@@ -69,8 +69,13 @@ type LabelerType int //nolint:revive
 
 // FincLabelerType returns the LabelerType of the label.
 func FindLabelerType(label *conventionalcommit.Cc) (LabelerType, bool) {
+	return FindLabelerTypeByShortName(label.Type())
+}
+
+// FindLabelerTypeByShortName returns the LabelerType of the short name (ex: feat).
+func FindLabelerTypeByShortName(name string) (LabelerType, bool) {
 	for k, v := range LabelsType {
-		if v.shortName == label.Type() {
+		if v.shortName == name {
 			return k, true
 		}
 	}
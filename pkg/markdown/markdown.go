@@ -0,0 +1,112 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	headingRe = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+	taskRe    = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	commentRe = regexp.MustCompile(`(?s)<!--.*?-->`)
+)
+
+// NoResponse is the content rendered by GitHub for an empty field of an issue form.
+const NoResponse = "_No response_"
+
+type Section struct {
+	// Level is the level of the heading (1 for #, 2 for ##...)
+	Level int
+	// Title is the text of the heading
+	Title string
+	// Content is the text between the heading and the next heading
+	Content string
+}
+
+type Task struct {
+	Text    string
+	Checked bool
+}
+
+// Sections splits the body on its headings.
+// Headings inside fenced code blocks are ignored.
+func Sections(body string) []Section {
+	sections := make([]Section, 0)
+
+	var (
+		current *Section
+		content []string
+		inCode  bool
+	)
+
+	flush := func() {
+		if current != nil {
+			current.Content = strings.TrimSpace(strings.Join(content, "\n"))
+			sections = append(sections, *current)
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+		}
+
+		if !inCode {
+			if m := headingRe.FindStringSubmatch(line); m != nil {
+				flush()
+				current = &Section{
+					Level: len(m[1]),
+					Title: m[2],
+				}
+				content = make([]string, 0)
+				continue
+			}
+		}
+
+		content = append(content, line)
+	}
+	flush()
+
+	return sections
+}
+
+// FindSection returns the section with the given title.
+// The title is compared case-insensitively.
+func FindSection(sections []Section, title string) (Section, bool) {
+	for _, s := range sections {
+		if strings.EqualFold(strings.TrimSpace(s.Title), strings.TrimSpace(title)) {
+			return s, true
+		}
+	}
+	return Section{}, false
+}
+
+// IsEmpty returns true if the content has no text once the HTML comments are removed.
+func IsEmpty(content string) bool {
+	c := strings.TrimSpace(StripComments(content))
+	return c == "" || c == NoResponse
+}
+
+// Tasks returns the task list items of the content.
+func Tasks(content string) []Task {
+	tasks := make([]Task, 0)
+	for _, line := range strings.Split(content, "\n") {
+		if m := taskRe.FindStringSubmatch(line); m != nil {
+			tasks = append(tasks, Task{
+				Text:    strings.TrimSpace(m[2]),
+				Checked: m[1] != " ",
+			})
+		}
+	}
+	return tasks
+}
+
+// Comments returns the HTML comments of the content.
+func Comments(content string) []string {
+	return commentRe.FindAllString(content, -1)
+}
+
+// StripComments removes the HTML comments of the content.
+func StripComments(content string) string {
+	return commentRe.ReplaceAllString(content, "")
+}
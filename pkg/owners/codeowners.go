@@ -0,0 +1,146 @@
+package owners
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/FrangipaneTeam/crown/pkg/ghclient"
+)
+
+// CodeOwners file
+// More details : https://docs.github.com/en/repositories/managing-your-repositorys-settings-and-features/customizing-your-repository/about-code-owners
+
+// CodeOwnersPaths are the locations of the CODEOWNERS file, in the order GitHub looks for them.
+var CodeOwnersPaths = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+var ErrCodeOwnersNotFound = errors.New("CODEOWNERS file not found")
+
+type Rule struct {
+	// Pattern is the pattern of the rule (ex: /docs/ or *.go)
+	Pattern string
+	// Owners is the list of the owners (ex: @user, @org/team or an email)
+	Owners []string
+
+	re *regexp.Regexp
+}
+
+type CodeOwners struct {
+	Rules []Rule
+}
+
+// ParseCodeOwners parses the content of a CODEOWNERS file.
+func ParseCodeOwners(content string) *CodeOwners {
+	c := &CodeOwners{
+		Rules: make([]Rule, 0),
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		c.Rules = append(c.Rules, Rule{
+			Pattern: fields[0],
+			Owners:  fields[1:],
+			re:      patternToRegexp(fields[0]),
+		})
+	}
+
+	return c
+}
+
+// LoadCodeOwners loads the CODEOWNERS file of the repository.
+func LoadCodeOwners(ghc *ghclient.GHClient) (*CodeOwners, error) {
+	for _, path := range CodeOwnersPaths {
+		content, err := ghc.GetFileContent(path)
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		return ParseCodeOwners(content), nil
+	}
+
+	return nil, ErrCodeOwnersNotFound
+}
+
+// isNotFound returns true if the error is a not found response of the API.
+func isNotFound(err error) bool {
+	var errResp *github.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound
+}
+
+// Owners returns the owners of the file.
+// The last matching rule wins, a rule without owner means the file has no owner.
+func (c *CodeOwners) Owners(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(c.Rules) - 1; i >= 0; i-- {
+		if c.Rules[i].re.MatchString(path) {
+			return c.Rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// patternToRegexp converts a gitignore like pattern to a regexp.
+// A pattern with a slash is relative to the root of the repository, otherwise it matches at any depth.
+func patternToRegexp(pattern string) *regexp.Regexp {
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	p := strings.TrimPrefix(pattern, "/")
+	dir := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; c {
+		case '*':
+			switch {
+			case i+2 < len(p) && p[i+1] == '*' && p[i+2] == '/':
+				// "**/" matches zero or more directories
+				b.WriteString("(?:.*/)?")
+				i += 2
+			case i+1 < len(p) && p[i+1] == '*':
+				b.WriteString(".*")
+				i++
+			default:
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	switch {
+	case dir:
+		b.WriteString("/.*$")
+	case strings.ContainsAny(p[strings.LastIndex(p, "/")+1:], "*?"):
+		// A wildcard in the last segment only matches the direct children (ex: docs/*)
+		b.WriteString("$")
+	default:
+		// A pattern matching a directory matches its content
+		b.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.MustCompile(b.String())
+}
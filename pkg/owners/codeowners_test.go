@@ -0,0 +1,50 @@
+package owners
+
+import "testing"
+
+func TestPatternToRegexp(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		// *
+		{"*", "README.md", true},
+		{"*", "docs/a/b.md", true},
+		{"*.go", "main.go", true},
+		{"*.go", "pkg/owners/owners.go", true},
+		{"*.go", "README.md", false},
+
+		// dir/*
+		{"docs/*", "docs/a.md", true},
+		{"docs/*", "docs/a/b.md", false},
+		{"docs/*", "other/docs/a.md", false},
+
+		// /dir/
+		{"/docs/", "docs/a.md", true},
+		{"/docs/", "docs/a/b.md", true},
+		{"/docs/", "other/docs/a.md", false},
+		{"docs/", "other/docs/a.md", true},
+
+		// dir without trailing slash
+		{"/docs", "docs/a/b.md", true},
+		{"/docs", "docs", true},
+
+		// **/x
+		{"**/logs", "logs", true},
+		{"**/logs", "build/logs/a.log", true},
+		{"**/logs", "build/logs", true},
+		{"**/logs", "build/mylogs", false},
+
+		// a/**
+		{"docs/**", "docs/a.md", true},
+		{"docs/**", "docs/a/b.md", true},
+		{"docs/**", "other/a.md", false},
+	}
+
+	for _, tt := range tests {
+		if got := patternToRegexp(tt.pattern).MatchString(tt.path); got != tt.want {
+			t.Errorf("pattern %q on %q = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
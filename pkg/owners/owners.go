@@ -0,0 +1,97 @@
+package owners
+
+import (
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/FrangipaneTeam/crown/pkg/ghclient"
+)
+
+// OWNERS file, as used by Prow
+// More details : https://www.kubernetes.dev/docs/guide/owners/
+
+// OwnersFileName is the name of the OWNERS files.
+const OwnersFileName = "OWNERS"
+
+type OwnersFile struct { //nolint:revive
+	// Approvers is the list of the users (ex: user) or teams (ex: org/team) who can approve the directory
+	Approvers []string `yaml:"approvers"`
+	// Reviewers is the list of the users or teams who can review the directory
+	Reviewers []string `yaml:"reviewers"`
+}
+
+// ParseOwnersFile parses the content of an OWNERS file.
+func ParseOwnersFile(content string) (*OwnersFile, error) {
+	o := &OwnersFile{}
+	if err := yaml.Unmarshal([]byte(content), o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// OwnersFiles are the OWNERS files of the repository, they are loaded on demand.
+type OwnersFiles struct { //nolint:revive
+	ghc *ghclient.GHClient
+	// files is keyed by directory, nil if the directory has no OWNERS file
+	files map[string]*OwnersFile
+}
+
+// NewOwnersFiles returns the OWNERS files of the repository.
+func NewOwnersFiles(ghc *ghclient.GHClient) *OwnersFiles {
+	return &OwnersFiles{
+		ghc:   ghc,
+		files: make(map[string]*OwnersFile),
+	}
+}
+
+// Approvers returns the approvers of the file, the approvers of a directory also approve its subdirectories.
+// The approvers are prefixed with @ like in a CODEOWNERS file (ex: @user, @org/team).
+func (o *OwnersFiles) Approvers(file string) ([]string, error) {
+	approvers := make([]string, 0)
+
+	dir := path.Dir(strings.TrimPrefix(file, "/"))
+	for {
+		f, err := o.load(dir)
+		if err != nil {
+			return nil, err
+		}
+		if f != nil {
+			for _, a := range f.Approvers {
+				approvers = append(approvers, "@"+strings.TrimPrefix(a, "@"))
+			}
+		}
+
+		if dir == "." {
+			break
+		}
+		dir = path.Dir(dir)
+	}
+
+	return approvers, nil
+}
+
+// load returns the OWNERS file of the directory, nil if the directory has no OWNERS file.
+func (o *OwnersFiles) load(dir string) (*OwnersFile, error) {
+	if f, ok := o.files[dir]; ok {
+		return f, nil
+	}
+
+	content, err := o.ghc.GetFileContent(path.Join(dir, OwnersFileName))
+	if err != nil {
+		if isNotFound(err) {
+			o.files[dir] = nil
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	f, err := ParseOwnersFile(content)
+	if err != nil {
+		return nil, err
+	}
+
+	o.files[dir] = f
+	return f, nil
+}
@@ -0,0 +1,134 @@
+package prtemplate
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/FrangipaneTeam/crown/pkg/ghclient"
+	"github.com/FrangipaneTeam/crown/pkg/markdown"
+)
+
+// Pull request template
+// More details : https://docs.github.com/en/communities/using-templates-to-encourage-useful-issues-and-pull-requests/creating-a-pull-request-template-for-your-repository
+
+// maxPlaceholderLength is the maximum length of a placeholder in the problems.
+const maxPlaceholderLength = 50
+
+// Paths is the list of the locations of the pull request template, in order of precedence.
+var Paths = []string{
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"pull_request_template.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	"docs/pull_request_template.md",
+	"docs/PULL_REQUEST_TEMPLATE.md",
+}
+
+// ErrTemplateNotFound is returned when the repository has no pull request template.
+var ErrTemplateNotFound = errors.New("pull request template not found")
+
+type Template struct {
+	// Path is the location of the template in the repository
+	Path         string
+	sections     []markdown.Section
+	placeholders []string
+}
+
+type Options struct {
+	// RequiredSections is the list of the sections which must be filled.
+	// If empty, all the sections of the template are required.
+	RequiredSections []string
+	// RequireCheckedTasks requires all the tasks of the checklists to be checked.
+	RequireCheckedTasks bool
+}
+
+// Parse parses a pull request template.
+func Parse(path, content string) *Template {
+	return &Template{
+		Path:         path,
+		sections:     markdown.Sections(content),
+		placeholders: markdown.Comments(content),
+	}
+}
+
+// Load returns the pull request template of the repository.
+// If the repository has no template, ErrTemplateNotFound is returned.
+func Load(ghc *ghclient.GHClient) (*Template, error) {
+	for _, path := range Paths {
+		content, err := ghc.GetFileContent(path)
+		if err != nil {
+			var errResp *github.ErrorResponse
+			if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, err
+		}
+		return Parse(path, content), nil
+	}
+
+	return nil, ErrTemplateNotFound
+}
+
+// Check returns the problems of the body compared to the template.
+func (t *Template) Check(body string, opts Options) []string {
+	problems := make([]string, 0)
+	sections := markdown.Sections(body)
+
+	required := opts.RequiredSections
+	if len(required) == 0 {
+		for _, s := range t.sections {
+			required = append(required, s.Title)
+		}
+	}
+
+	// Required sections
+	for _, title := range required {
+		section, ok := markdown.FindSection(sections, title)
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("Section `%s` is missing", title))
+		case markdown.IsEmpty(section.Content):
+			problems = append(problems, fmt.Sprintf("Section `%s` is empty", title))
+		}
+	}
+
+	// Checklists
+	if opts.RequireCheckedTasks {
+		for _, ts := range t.sections {
+			if len(markdown.Tasks(ts.Content)) == 0 {
+				continue
+			}
+			section, ok := markdown.FindSection(sections, ts.Title)
+			if !ok {
+				continue
+			}
+			for _, task := range markdown.Tasks(section.Content) {
+				if !task.Checked {
+					problems = append(problems, fmt.Sprintf("Task `%s` of section `%s` is not checked", task.Text, ts.Title))
+				}
+			}
+		}
+	}
+
+	// Placeholders
+	for _, placeholder := range t.placeholders {
+		if strings.Contains(body, placeholder) {
+			problems = append(problems, fmt.Sprintf("Placeholder `%s` is not removed", shorten(placeholder)))
+		}
+	}
+
+	return problems
+}
+
+// shorten returns the placeholder on a single line truncated to maxPlaceholderLength.
+func shorten(placeholder string) string {
+	x := strings.Join(strings.Fields(placeholder), " ")
+	if len(x) > maxPlaceholderLength {
+		x = x[:maxPlaceholderLength-3] + "..."
+	}
+	return x
+}
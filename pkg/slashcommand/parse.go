@@ -0,0 +1,107 @@
+package slashcommand
+
+import (
+	"regexp"
+	"strings"
+)
+
+// commandRe matches a slash command at the beginning of a line (ex: /label add foo or /label:add foo).
+var commandRe = regexp.MustCompile(`^/([a-zA-Z][\w-]*)(?::([\w-]+))?(?:\s+(.*))?$`)
+
+// Invocation is a slash command found in a comment.
+type Invocation struct {
+	// Name is the name of the command (ex: label)
+	Name string
+	// Verb is the verb of the command (ex: add), empty if the command has no verb
+	Verb string
+	// Args is the list of the arguments, quotes are removed
+	Args []string
+	// Raw is the text after the command, quotes are kept
+	Raw string
+	// Line is the line of the comment
+	Line string
+}
+
+// Parse finds the slash commands in the body of a comment.
+// A command must start a line, the commands in code blocks and quotes are ignored.
+func Parse(body string) []Invocation {
+	var (
+		invocations = make([]Invocation, 0)
+		fence       = ""
+	)
+
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		// Fenced code block
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		// Indented code block and quote
+		if strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+
+		m := commandRe.FindStringSubmatch(trimmed)
+		if m == nil {
+			continue
+		}
+
+		invocations = append(invocations, Invocation{
+			Name: strings.ToLower(m[1]),
+			Verb: strings.ToLower(m[2]),
+			Args: splitArgs(m[3]),
+			Raw:  strings.TrimSpace(m[3]),
+			Line: trimmed,
+		})
+	}
+
+	return invocations
+}
+
+// splitArgs splits the arguments on spaces.
+// An argument between double or single quotes may contain spaces.
+func splitArgs(s string) []string {
+	var (
+		args    = make([]string, 0)
+		current strings.Builder
+		quote   rune
+		inArg   bool
+	)
+
+	for _, r := range s {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	// An unclosed quote ends at the end of the line
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args
+}
@@ -0,0 +1,135 @@
+package slashcommand
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/FrangipaneTeam/crown/pkg/config"
+)
+
+// Level is the permission level of a collaborator of the repository.
+type Level int
+
+const (
+	// LevelUnset means the permission has no level condition
+	LevelUnset Level = iota - 1
+	LevelNone
+	LevelRead
+	LevelTriage
+	LevelWrite
+	LevelMaintain
+	LevelAdmin
+)
+
+// ParseLevel returns the level of the role name or the permission returned by GitHub.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(s) {
+	case "", "none":
+		return LevelNone, nil
+	case "read", "pull":
+		return LevelRead, nil
+	case "triage":
+		return LevelTriage, nil
+	case "write", "push":
+		return LevelWrite, nil
+	case "maintain":
+		return LevelMaintain, nil
+	case "admin":
+		return LevelAdmin, nil
+	default:
+		return LevelNone, fmt.Errorf("unknown permission level %s", s)
+	}
+}
+
+// String returns the name of the level.
+func (l Level) String() string {
+	switch l {
+	case LevelUnset:
+		return "unset"
+	case LevelNone:
+		return "none"
+	case LevelRead:
+		return "read"
+	case LevelTriage:
+		return "triage"
+	case LevelWrite:
+		return "write"
+	case LevelMaintain:
+		return "maintain"
+	case LevelAdmin:
+		return "admin"
+	default:
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+}
+
+// Permission is the permission required to run a command.
+// The user is allowed if one of the conditions is met.
+type Permission struct {
+	// Level is the minimum permission level on the repository, LevelNone or LevelUnset is not a condition
+	Level Level
+	// Teams is the list of the teams allowed (ex: FrangipaneTeam/maintainers)
+	Teams []string
+	// CodeOwners allows the owners of all the files changed by the pull request
+	CodeOwners bool
+	// Author allows the author of the issue or the pull request
+	Author bool
+}
+
+// IsPublic returns true if everyone is allowed, the level is none and there is no other condition.
+func (p Permission) IsPublic() bool {
+	return p.Level == LevelNone && len(p.Teams) == 0 && !p.CodeOwners && !p.Author
+}
+
+// HasLevel returns true if the permission requires a permission level on the repository.
+func (p Permission) HasLevel() bool {
+	return p.Level > LevelNone
+}
+
+// Describe returns who is allowed to run the command.
+func (p Permission) Describe() string {
+	if p.IsPublic() {
+		return "anyone"
+	}
+
+	x := make([]string, 0)
+	if p.HasLevel() {
+		x = append(x, fmt.Sprintf("collaborators with %s permission", p.Level))
+	}
+	if len(p.Teams) > 0 {
+		x = append(x, "members of "+strings.Join(p.Teams, ", "))
+	}
+	if p.CodeOwners {
+		x = append(x, "code owners of the changed files")
+	}
+	if p.Author {
+		x = append(x, "the author")
+	}
+
+	switch len(x) {
+	case 0:
+		return "nobody"
+	case 1:
+		return x[0]
+	}
+	return strings.Join(x[:len(x)-1], ", ") + " or " + x[len(x)-1]
+}
+
+// PermissionFromConfig returns the permission of the configuration.
+// An empty level is not a condition, the command is public only if the level is explicitly none.
+func PermissionFromConfig(c config.PermissionConfig) (Permission, error) {
+	l := LevelUnset
+	if c.Level != "" {
+		var err error
+		if l, err = ParseLevel(c.Level); err != nil {
+			return Permission{}, err
+		}
+	}
+
+	return Permission{
+		Level:      l,
+		Teams:      c.Teams,
+		CodeOwners: c.CodeOwners,
+		Author:     c.Author,
+	}, nil
+}
@@ -0,0 +1,174 @@
+package slashcommand
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrUnknownCommand = errors.New("unknown command")
+	ErrInvalidVerb    = errors.New("invalid verb")
+	ErrInvalidArgs    = errors.New("invalid number of arguments")
+)
+
+// Args is the schema of the arguments of a command.
+type Args struct {
+	// Min is the minimum number of arguments
+	Min int
+	// Max is the maximum number of arguments, -1 for unlimited
+	Max int
+	// Usage describes the arguments (ex: <label>...)
+	Usage string
+}
+
+// HandlerFunc runs a command.
+type HandlerFunc func(inv Invocation) error
+
+type Command struct {
+	// Name is the name of the command (ex: label)
+	Name string
+	// Verbs is the list of the verbs of the command, the command has no verb if empty
+	Verbs []string
+	// DefaultVerb is used when the command is called without verb
+	DefaultVerb string
+	// Args is the schema of the arguments
+	Args Args
+	// Permission is the permission required to run the command
+	Permission Permission
+	// PullRequestOnly is true if the command can only be run on a pull request
+	PullRequestOnly bool
+	// Description is displayed in the help
+	Description string
+	// Handler runs the command
+	Handler HandlerFunc
+}
+
+// Usage returns the usage of the command (ex: /label add|remove <label>...).
+func (c *Command) Usage() string {
+	x := "/" + c.Name
+	if len(c.Verbs) > 0 {
+		x += " " + strings.Join(c.Verbs, "|")
+	}
+	if c.Args.Usage != "" {
+		x += " " + c.Args.Usage
+	}
+	return x
+}
+
+// hasVerb returns true if the verb is a verb of the command.
+func (c *Command) hasVerb(verb string) bool {
+	for _, v := range c.Verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
+
+type Registry struct {
+	commands map[string]*Command
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		commands: make(map[string]*Command),
+	}
+}
+
+// Register adds the command to the registry.
+// A command with the same name is replaced.
+func (r *Registry) Register(cmd Command) {
+	r.commands[cmd.Name] = &cmd
+}
+
+// SetPermission overrides the permission of the command.
+func (r *Registry) SetPermission(name string, p Permission) bool {
+	c, ok := r.commands[name]
+	if ok {
+		c.Permission = p
+	}
+	return ok
+}
+
+// Lookup returns the command with the name.
+func (r *Registry) Lookup(name string) (*Command, bool) {
+	c, ok := r.commands[name]
+	return c, ok
+}
+
+// Commands returns the commands sorted by name.
+func (r *Registry) Commands() []*Command {
+	x := make([]*Command, 0, len(r.commands))
+	for _, c := range r.commands {
+		x = append(x, c)
+	}
+	sort.Slice(x, func(i, j int) bool {
+		return x[i].Name < x[j].Name
+	})
+	return x
+}
+
+// Resolve returns the command of the invocation and the invocation with its verb resolved.
+// The verb is either the suffix of the command (/label:add) or its first argument (/label add).
+func (r *Registry) Resolve(inv Invocation) (*Command, Invocation, error) {
+	cmd, ok := r.Lookup(inv.Name)
+	if !ok {
+		return nil, inv, ErrUnknownCommand
+	}
+
+	if len(cmd.Verbs) > 0 {
+		switch {
+		case inv.Verb != "":
+			// /label:add foo
+		case len(inv.Args) > 0 && cmd.hasVerb(strings.ToLower(inv.Args[0])):
+			inv.Verb = strings.ToLower(inv.Args[0])
+			inv.Args = inv.Args[1:]
+		default:
+			inv.Verb = cmd.DefaultVerb
+		}
+
+		if !cmd.hasVerb(inv.Verb) {
+			return cmd, inv, ErrInvalidVerb
+		}
+	} else if inv.Verb != "" {
+		return cmd, inv, ErrInvalidVerb
+	}
+
+	if len(inv.Args) < cmd.Args.Min || (cmd.Args.Max >= 0 && len(inv.Args) > cmd.Args.Max) {
+		return cmd, inv, ErrInvalidArgs
+	}
+
+	return cmd, inv, nil
+}
+
+// Help returns the help of the commands as a markdown table.
+func (r *Registry) Help() string {
+	x := "| Command | Description | Permission |\n| --- | --- | --- |\n"
+	for _, c := range r.Commands() {
+		desc := c.Description
+		if c.PullRequestOnly {
+			desc += " (pull requests only)"
+		}
+		x += fmt.Sprintf("| `%s` | %s | %s |\n", c.Usage(), desc, c.Permission.Describe())
+	}
+	return x
+}
+
+// UsageError is an error caused by the arguments of a command.
+// The error is replied to the user with the usage of the command.
+type UsageError struct {
+	msg string
+}
+
+// NewUsageError returns a new UsageError.
+func NewUsageError(format string, a ...interface{}) error {
+	return &UsageError{msg: fmt.Sprintf(format, a...)}
+}
+
+// Error returns the message of the error.
+func (e *UsageError) Error() string {
+	return e.msg
+}
@@ -4,18 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"time"
 
-	"github.com/bradleyfalzon/ghinstallation"
 	"github.com/google/go-github/v47/github"
 
-	"github.com/FrangipaneTeam/crown/pkg/config"
 	"github.com/FrangipaneTeam/crown/pkg/db"
 )
 
 const (
-	closed = "closed"
+	closed   = "closed"
+	open     = "open"
+	merged   = "merged"
+	reopened = "reopened"
 )
 
 type TrackIssue struct {
@@ -23,14 +23,17 @@ type TrackIssue struct {
 	base trackBase
 }
 
-func TrackNewIssue(repoOwner, repoName string, installationID, issueID int64, trackIssueURL string) error {
+// TrackNewIssue tracks the upstream issue referenced by trackIssueURL from the source issue.
+// The record is keyed by the upstream issue, so several issues can track the same upstream issue.
+// The source is the type of the issue or the pull request which tracks the upstream issue.
+func TrackNewIssue(source TypeResource, repoOwner, repoName string, installationID, issueID int64, trackIssueURL string) error {
 	// Parse TrackIssueURL
 	ghRepository, err := parseTrackIssueURL(trackIssueURL)
 	if err != nil {
 		return err
 	}
 
-	pathDB := generatePathDB(TypeIssue, installationID, repoOwner, repoName, issueID)
+	pathDB := generatePathDB(source, installationID, ghRepository.GetRepoOwner(), ghRepository.GetRepoName(), ghRepository.GetID())
 
 	// Find if the issue is already tracked
 	if ok := db.TrackDB().KeyExists(db.Byte(pathDB)); ok {
@@ -39,6 +42,7 @@ func TrackNewIssue(repoOwner, repoName string, installationID, issueID int64, tr
 			if err := json.Unmarshal(raw, &t); err == nil {
 				logger.Debug().Msgf("Issue already tracked: %s", pathDB)
 				t.AddSourceRepository(repoOwner, repoName, issueID)
+				t.Activate()
 
 				tJSON, err := json.Marshal(t)
 				if err != nil {
@@ -68,13 +72,13 @@ func TrackNewIssue(repoOwner, repoName string, installationID, issueID int64, tr
 				},
 				InstallationID:   installationID,
 				StatusOfLastScan: false,
+				State:            StateActive,
 			},
 		}
 
 		t.base.LastScanAt.Now()
 		t.base.CreateAt.Now()
 		t.base.UpdateAt.Now()
-		t.base.ClosedAt.Now()
 
 		tJSON, err := json.Marshal(t.base)
 		if err != nil {
@@ -88,24 +92,24 @@ func TrackNewIssue(repoOwner, repoName string, installationID, issueID int64, tr
 }
 
 // newGithubClient returns a new github client.
+// The client is provided by the shared ClientCreator, so the installation
+// clients are cached and share the middlewares of the webhook handlers.
 func (c *TrackIssue) newGithubClient() error {
-	if config.AppID == 0 || c.base.InstallationID == 0 {
-		return fmt.Errorf("app id or installation id is not set")
+	if clientCreator == nil {
+		return fmt.Errorf("client creator is not set")
 	}
 
-	if config.PrivateKey == nil {
-		return fmt.Errorf("private key is not set")
+	if c.base.InstallationID == 0 {
+		return fmt.Errorf("installation id is not set")
 	}
 
-	itr, err := ghinstallation.New(http.DefaultTransport, config.AppID, c.base.InstallationID, config.PrivateKey)
+	ghc, err := clientCreator.NewInstallationClient(c.base.InstallationID)
 	if err != nil {
 		return err
 	}
 
-	c.core.ctx, c.core.cancel = context.WithTimeout(context.Background(), 30*time.Second)
-
-	// Use installation transport with client.
-	c.core.ghc = github.NewClient(&http.Client{Transport: itr})
+	c.core.ctx, c.core.cancel = context.WithTimeout(context.Background(), scanTimeout)
+	c.core.ghc = ghc
 
 	return nil
 }
@@ -117,7 +121,11 @@ func (g *GithubRepository) githubParams() (string, string, int) {
 }
 
 // ScanIsNecessary checks if the issue is already tracked.
+// Archived issues are never scanned.
 func (c *TrackIssue) ScanIsNecessary() bool {
+	if c.base.IsArchived() {
+		return false
+	}
 	return c.base.LastScanAt.Time.Before(time.Now().Add(-intervalScanIssue)) || !c.base.StatusOfLastScan
 }
 
@@ -133,41 +141,17 @@ func (c *TrackIssue) Scan() error {
 			return err
 		}
 	}
+	defer c.core.cancel()
 
-	timeline, _, err := c.core.ghc.Issues.ListIssueTimeline(c.core.ctx, c.base.TargetRepository.RepoOwner, c.base.TargetRepository.RepoName, int(c.base.TargetRepository.ID), nil)
+	timeline, err := c.listTimeline()
 	if err != nil {
 		return err
 	}
 
-	if c.base.Timeline == nil {
-		c.base.Timeline = make([]GithubTimeline, 0)
-	}
-
-	if len(c.base.Timeline) != len(timeline) {
-		for _, event := range timeline {
-			if c.base.LastScanAt.Time.After(event.GetCreatedAt()) {
-				continue
-			}
-			if !c.base.IsExist(event.GetID()) {
-				x := GithubTimeline{
-					Event:    event.GetEvent(),
-					CommitID: event.GetCommitID(),
-					Body:     event.GetBody(),
-					Message:  event.GetMessage(),
-					State:    event.GetState(),
-
-					Rename: GithubRename{
-						From: event.GetRename().GetFrom(),
-						To:   event.GetRename().GetTo(),
-					},
-				}
-				x.CreatedAt.Time = event.GetCreatedAt()
-				x.SubmittedAt.Time = event.GetSubmittedAt()
-
-				c.base.Timeline = append(c.base.Timeline, x)
-			}
-		}
-	}
+	c.base.migrateTimeline()
+	defer func() {
+		c.base.legacySeen = nil
+	}()
 
 	if c.base.Status == "" || c.base.Title == "" {
 		issue, _, err := c.core.ghc.Issues.Get(c.core.ctx, c.base.TargetRepository.RepoOwner, c.base.TargetRepository.RepoName, int(c.base.TargetRepository.ID))
@@ -178,18 +162,31 @@ func (c *TrackIssue) Scan() error {
 		c.base.Title = issue.GetTitle()
 	}
 
-	for _, event := range timeline {
-		// if evenc.CreatedAt after last scan
-		// ignore event
-		if c.base.LastScanAt.Time.After(event.GetCreatedAt()) {
+	for _, te := range timeline {
+		event := te.event
+		if !c.base.isNewEvent(event, te.page, te.index) {
+			// The events seen during the migration are recorded in the cursor
+			if c.base.legacySeen != nil {
+				c.base.addTimelineEvent(event, te.page, te.index)
+			}
+			continue
+		}
+		c.base.addTimelineEvent(event, te.page, te.index)
+
+		// Events which occurred before the issue was tracked are only recorded
+		if c.base.CreateAt.Time.After(event.GetCreatedAt()) {
 			continue
 		}
 
 		switch event.GetEvent() {
 		case "commented":
 			newUpdate = true
-		case closed:
+		case closed, merged:
 			c.base.Status = closed
+			c.base.ClosedAt.Time = event.GetCreatedAt()
+			newUpdate = true
+		case reopened:
+			c.base.Status = open
 			newUpdate = true
 		}
 	}
@@ -207,12 +204,103 @@ func (c *TrackIssue) Scan() error {
 		}
 	}
 
+	if err = c.updateState(); err != nil {
+		logger.Error().Err(err).Msg("error update state")
+	}
+
 	c.base.LastScanAt.Time = time.Now()
 	c.base.StatusOfLastScan = true
 	logger.Debug().Msgf("End scan issue %s/%s/%d", c.base.TargetRepository.RepoOwner, c.base.TargetRepository.RepoName, c.base.TargetRepository.ID)
 	return nil
 }
 
+// updateState moves the issue through the lifecycle states.
+// A closed issue is untracked when the grace period is over and all the source issues are closed.
+func (c *TrackIssue) updateState() error {
+	if !c.IsClose() {
+		c.base.Activate()
+		return nil
+	}
+
+	if c.base.GetState() == StateActive {
+		if c.base.ClosedAt.IsZero() {
+			c.base.ClosedAt.Now()
+		}
+		c.base.State = StateClosedGrace
+		logger.Debug().Msgf("Issue %s/%s/%d is closed, grace period started", c.base.TargetRepository.RepoOwner, c.base.TargetRepository.RepoName, c.base.TargetRepository.ID)
+	}
+
+	if time.Since(c.base.ClosedAt.Time) < trackerConfig.GracePeriod {
+		return nil
+	}
+
+	sourcesClosed, err := c.sourcesAreClosed()
+	if err != nil {
+		return err
+	}
+
+	if sourcesClosed {
+		c.base.Archive()
+		logger.Debug().Msgf("Issue %s/%s/%d is untracked", c.base.TargetRepository.RepoOwner, c.base.TargetRepository.RepoName, c.base.TargetRepository.ID)
+	}
+
+	return nil
+}
+
+// sourcesAreClosed checks if all the source issues are closed.
+func (c *TrackIssue) sourcesAreClosed() (bool, error) {
+	for _, source := range c.base.SourcesRepository {
+		repoO, repoN, issueID := source.githubParams()
+		issue, _, err := c.core.ghc.Issues.Get(c.core.ctx, repoO, repoN, issueID)
+		if err != nil {
+			return false, err
+		}
+		if issue.GetState() != closed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+type timelineEvent struct {
+	event *github.Timeline
+	page  int
+	// index is the position of the event on its page
+	index int
+}
+
+// listTimeline returns the timeline events of the issue.
+// The listing starts at the page of the cursor and follows the pagination
+// until the last page.
+func (c *TrackIssue) listTimeline() ([]timelineEvent, error) {
+	opts := &github.ListOptions{
+		Page:    c.base.Cursor.Page,
+		PerPage: timelinePerPage,
+	}
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+
+	var events []timelineEvent
+	for {
+		timeline, resp, err := c.core.ghc.Issues.ListIssueTimeline(c.core.ctx, c.base.TargetRepository.RepoOwner, c.base.TargetRepository.RepoName, int(c.base.TargetRepository.ID), opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, event := range timeline {
+			events = append(events, timelineEvent{event: event, page: opts.Page, index: i})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return events, nil
+}
+
 // IsClose checks if the issue is closed.
 func (c *TrackIssue) IsClose() bool {
 	return c.base.Status == closed
@@ -0,0 +1,51 @@
+package tracker
+
+import "time"
+
+type TrackState string
+
+const (
+	// StateActive is the state of a tracked item which is open.
+	StateActive TrackState = "active"
+	// StateClosedGrace is the state of a tracked item which is closed but still scanned.
+	StateClosedGrace TrackState = "closed-grace"
+	// StateArchived is the state of an untracked item waiting to be purged.
+	StateArchived TrackState = "archived"
+)
+
+// String returns the string representation of the state.
+func (s TrackState) String() string {
+	return string(s)
+}
+
+// GetState return the lifecycle state of the tracked item.
+// Items recorded before the lifecycle states are considered active.
+func (t *trackBase) GetState() TrackState {
+	if t.State == "" {
+		return StateActive
+	}
+	return t.State
+}
+
+// IsArchived checks if the tracked item is untracked.
+func (t *trackBase) IsArchived() bool {
+	return t.GetState() == StateArchived
+}
+
+// Activate sets the tracked item as active.
+func (t *trackBase) Activate() {
+	t.State = StateActive
+	t.ClosedAt = Timestamp{}
+	t.ArchivedAt = Timestamp{}
+}
+
+// Archive untracks the item.
+func (t *trackBase) Archive() {
+	t.State = StateArchived
+	t.ArchivedAt.Now()
+}
+
+// IsPurgeable checks if the retention of the untracked item is over.
+func (t *trackBase) IsPurgeable() bool {
+	return t.IsArchived() && time.Since(t.ArchivedAt.Time) > trackerConfig.Retention
+}
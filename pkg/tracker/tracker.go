@@ -6,21 +6,32 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v47/github"
+	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/rs/zerolog"
 
 	"github.com/FrangipaneTeam/crown/pkg/common"
+	"github.com/FrangipaneTeam/crown/pkg/config"
 )
 
-var logger zerolog.Logger
+var (
+	logger        zerolog.Logger
+	clientCreator githubapp.ClientCreator
+	trackerConfig config.TrackerConfig
+)
 
 type TypeResource string
 
 const (
 	intervalScanIssue = 10 * time.Minute
 	intervalScanPR    = 10 * time.Minute
+	scanTimeout       = 30 * time.Second
+
+	// timelinePerPage is the number of timeline events requested per page.
+	timelinePerPage = 100
 
 	TypeIssue   TypeResource = "issues"
 	TypePR      TypeResource = "pullrequests"
@@ -48,6 +59,11 @@ func (g *GithubRepository) GetID() int64 {
 	return g.ID
 }
 
+// String returns the reference of the issue in the form repoOwner/repoName#ID.
+func (g GithubRepository) String() string {
+	return fmt.Sprintf("%s/%s#%d", g.RepoOwner, g.RepoName, g.ID)
+}
+
 type GithubRename struct {
 	From string `json:"from,omitempty"`
 	To   string `json:"to,omitempty"`
@@ -156,6 +172,30 @@ type GithubTimeline struct {
 	SubmittedAt Timestamp `json:"submitted_at,omitempty"`
 }
 
+// TimelineCursor is the position of the last timeline event seen by the tracker.
+// The timeline is only listed from the page of the cursor, so only the events of this page are remembered.
+type TimelineCursor struct {
+	// LastEventAt is the timestamp of the last timeline event seen
+	LastEventAt Timestamp `json:"last_event_at"`
+	// Page is the timeline page where the last event was seen
+	Page int `json:"page,omitempty"`
+	// Seen is the list of the keys of the events seen on the page (see timelineKey)
+	Seen []string `json:"seen,omitempty"`
+}
+
+// timelineKey returns the key of the timeline event on its page.
+// The events without ID are identified by their commit SHA (committed) or their index on the page.
+func timelineKey(event *github.Timeline, index int) string {
+	switch {
+	case event.GetID() != 0:
+		return "id:" + strconv.FormatInt(event.GetID(), 10)
+	case event.GetSHA() != "":
+		return "sha:" + event.GetSHA()
+	default:
+		return "idx:" + strconv.Itoa(index)
+	}
+}
+
 // generatePathDB generate the path of the key in the database.
 func generatePathDB(x TypeResource, installationID int64, repoOwner, repoName string, repoID int64) string {
 	// format of the key in the database is
@@ -198,17 +238,85 @@ type trackBase struct {
 	CreateAt Timestamp `json:"create_at"`
 	UpdateAt Timestamp `json:"update_at"`
 	ClosedAt Timestamp `json:"closed_at"`
-	Timeline []GithubTimeline
+
+	// State is the lifecycle state of the tracked item
+	State TrackState `json:"state"`
+	// ArchivedAt is the timestamp of the untracking
+	ArchivedAt Timestamp `json:"archived_at"`
+
+	// Cursor is the position of the last timeline event seen
+	Cursor TimelineCursor `json:"cursor"`
+
+	// LegacyTimeline is the list of the timeline events stored by the previous versions,
+	// it's only read once to migrate to the cursor (see migrateTimeline)
+	LegacyTimeline []GithubTimeline `json:"Timeline,omitempty"`
+	// LegacyTimelineEvents is the map of the timeline events stored by the previous versions
+	LegacyTimelineEvents map[int64]GithubTimeline `json:"timeline_events,omitempty"`
+
+	// legacySeen is the set of the timeline event IDs seen by the previous versions, during the migration
+	legacySeen map[int64]bool
 }
 
-// IsExist check if the ID is already in the list.
-func (t *trackBase) IsExist(iD int64) bool {
-	for _, x := range t.SourcesRepository {
-		if x.GetID() == iD {
-			return true
+// migrateTimeline loads the timeline events stored by the previous versions.
+// Their IDs are considered as seen during the next scan, then the stored events are dropped.
+func (t *trackBase) migrateTimeline() {
+	if len(t.LegacyTimeline) == 0 && len(t.LegacyTimelineEvents) == 0 {
+		return
+	}
+
+	t.legacySeen = make(map[int64]bool)
+	for _, e := range t.LegacyTimeline {
+		t.legacySeen[e.ID] = true
+		if e.CreatedAt.After(t.Cursor.LastEventAt.Time) {
+			t.Cursor.LastEventAt.Time = e.CreatedAt.Time
 		}
 	}
-	return false
+	for id, e := range t.LegacyTimelineEvents {
+		t.legacySeen[id] = true
+		if e.CreatedAt.After(t.Cursor.LastEventAt.Time) {
+			t.Cursor.LastEventAt.Time = e.CreatedAt.Time
+		}
+	}
+
+	t.LegacyTimeline = nil
+	t.LegacyTimelineEvents = nil
+}
+
+// isNewEvent check if the timeline event has not been seen yet.
+func (t *trackBase) isNewEvent(event *github.Timeline, page, index int) bool {
+	if t.legacySeen != nil {
+		// Migration, the events without ID are compared with the last event stored
+		if event.GetID() != 0 {
+			return !t.legacySeen[event.GetID()]
+		}
+		return event.GetCreatedAt().After(t.Cursor.LastEventAt.Time)
+	}
+
+	if page != t.Cursor.Page {
+		// The listing starts at the page of the cursor
+		return page > t.Cursor.Page
+	}
+
+	key := timelineKey(event, index)
+	for _, k := range t.Cursor.Seen {
+		if k == key {
+			return false
+		}
+	}
+	return true
+}
+
+// addTimelineEvent moves the cursor to the timeline event.
+func (t *trackBase) addTimelineEvent(event *github.Timeline, page, index int) {
+	if page != t.Cursor.Page {
+		t.Cursor.Page = page
+		t.Cursor.Seen = nil
+	}
+	t.Cursor.Seen = append(t.Cursor.Seen, timelineKey(event, index))
+
+	if event.GetCreatedAt().After(t.Cursor.LastEventAt.Time) {
+		t.Cursor.LastEventAt.Time = event.GetCreatedAt()
+	}
 }
 
 // GetLastScanAt return the last scan timestamp.
@@ -261,9 +369,9 @@ func (t *trackBase) GetClosedAt() Timestamp {
 	return t.ClosedAt
 }
 
-// GetTimeline return the timeline of the issue/pr.
-func (t *trackBase) GetTimeline() []GithubTimeline {
-	return t.Timeline
+// GetCursor return the position of the last timeline event seen.
+func (t *trackBase) GetCursor() TimelineCursor {
+	return t.Cursor
 }
 
 type TrackPR struct {
@@ -274,8 +382,11 @@ type TrackPR struct {
 }
 
 // Init initialize the tracker.
-func Init(x zerolog.Logger) {
+// cc is the ClientCreator shared with the webhook handlers.
+func Init(x zerolog.Logger, cc githubapp.ClientCreator, cfg config.TrackerConfig) {
 	logger = x
+	clientCreator = cc
+	trackerConfig = cfg
 }
 
 // parseTrackIssueURL parse the TrackIssueURL and return the repoOwner, repoName, id and error.
@@ -315,6 +426,41 @@ func parseTrackIssueURL(trackIssueURL string) (GithubRepository, error) {
 	return GithubRepository{}, fmt.Errorf("unable to parse TrackIssueURL")
 }
 
+// ParseReference parses a reference to an issue (ex: FrangipaneTeam/crown#1).
+func ParseReference(ref string) (GithubRepository, error) {
+	return parseTrackIssueURL(ref)
+}
+
+// trackKeywordRe matches the references preceded by a tracking keyword.
+var trackKeywordRe = regexp.MustCompile(`(?i)(?:depends\s+on|blocked\s+by)\s*:?\s+(\S+)`)
+
+// FindReferences returns the issues referenced with a tracking keyword in the body.
+// Keywords are "depends on" and "blocked by", the reference must be parseable by parseTrackIssueURL
+// (ex: Depends on FrangipaneTeam/crown#1 or Blocked by https://github.com/FrangipaneTeam/crown/issues/140).
+func FindReferences(body string) []GithubRepository {
+	refs := make([]GithubRepository, 0)
+
+	for _, match := range trackKeywordRe.FindAllStringSubmatch(body, -1) {
+		ref, err := parseTrackIssueURL(strings.TrimRight(match[1], ".,;:)"))
+		if err != nil {
+			continue
+		}
+
+		found := false
+		for _, r := range refs {
+			if r == ref {
+				found = true
+				break
+			}
+		}
+		if !found {
+			refs = append(refs, ref)
+		}
+	}
+
+	return refs
+}
+
 // sourceRepositoryAlreadyExist check if the source repository is already in the list.
 func (t *trackBase) sourceRepositoryAlreadyExist(repoOwner, repoName string, issueID int64) bool {
 	for _, s := range t.SourcesRepository {
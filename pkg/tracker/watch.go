@@ -14,57 +14,108 @@ const (
 	intervalLoopWatch = 30 * time.Second
 )
 
+// trackedItem is a record of the database read by the watcher.
+type trackedItem struct {
+	key   []byte
+	value []byte
+}
+
 // Watch is watcher of the issue/pr
 // It is responsible for scanning the issue/pr
 // and updating the database.
+// The records are read in a single transaction, the scans run outside any transaction
+// and each record is saved in its own transaction.
 func Watch() {
 	for {
 		logger.Trace().Msg("Start watching")
-		// Get all the repos
-		err := db.DataBase.Update(func(tx *bbolt.Tx) error {
-			// Assume bucket exists and has keys
-			c := tx.Bucket([]byte(db.TrackDB().Bucket())).Cursor()
-
-			prefix := []byte("issues/")
-			for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
-				// For each issue
-				var x trackBase
-				err := json.Unmarshal(v, &x)
-				if err != nil {
-					logger.Error().Err(err).Msgf("Error while unmarshaling issue %s", k)
-					return err
-				}
-
-				issue := &TrackIssue{
-					base: x,
-				}
-
-				if issue.ScanIsNecessary() {
-					logger.Debug().Msgf("Scan necessary for issue %s", k)
-					if err := issue.Scan(); err != nil {
-						logger.Error().Err(err).Msgf("Error while scanning issue %s", k)
-						continue
-					}
-
-					issueJ, err := json.Marshal(issue.base)
-					if err != nil {
-						logger.Error().Err(err).Msgf("Error while marshaling issue %s", k)
-						continue
-					}
-					if err := c.Bucket().Put(k, issueJ); err != nil {
-						logger.Error().Err(err).Msgf("Error while saving issue %s", k)
-						continue
-					}
-				}
-			}
 
-			return nil
-		})
+		items, err := listTrackedItems()
 		if err != nil {
 			logger.Error().Err(err).Msg("Error while watching")
 		}
 
+		for _, item := range items {
+			watchItem(item)
+		}
+
 		logger.Trace().Msg("End watching waiting for next loop")
 		time.Sleep(intervalLoopWatch)
 	}
 }
+
+// listTrackedItems returns the upstream issues tracked by the issues and by the pull requests.
+func listTrackedItems() ([]trackedItem, error) {
+	items := make([]trackedItem, 0)
+	err := db.DataBase.View(func(tx *bbolt.Tx) error {
+		// Assume bucket exists and has keys
+		c := tx.Bucket([]byte(db.TrackDB().Bucket())).Cursor()
+
+		for _, source := range []TypeResource{TypeIssue, TypePR} {
+			prefix := []byte(source + "/")
+			for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+				// The slices are only valid during the transaction
+				items = append(items, trackedItem{
+					key:   append([]byte{}, k...),
+					value: append([]byte{}, v...),
+				})
+			}
+		}
+		return nil
+	})
+	return items, err
+}
+
+// watchItem purges or scans the tracked issue and saves the result.
+func watchItem(item trackedItem) {
+	var x trackBase
+	if err := json.Unmarshal(item.value, &x); err != nil {
+		logger.Error().Err(err).Msgf("Error while unmarshaling issue %s", item.key)
+		return
+	}
+
+	issue := &TrackIssue{
+		base: x,
+	}
+
+	if issue.base.IsPurgeable() {
+		logger.Debug().Msgf("Purge untracked issue %s", item.key)
+		if err := db.TrackDB().Delete(item.key); err != nil {
+			logger.Error().Err(err).Msgf("Error while purging issue %s", item.key)
+		}
+		return
+	}
+
+	if !issue.ScanIsNecessary() {
+		return
+	}
+
+	logger.Debug().Msgf("Scan necessary for issue %s", item.key)
+	if err := issue.Scan(); err != nil {
+		logger.Error().Err(err).Msgf("Error while scanning issue %s", item.key)
+		return
+	}
+
+	err := db.DataBase.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(db.TrackDB().Bucket()))
+
+		// The sources added while scanning are kept
+		if v := b.Get(item.key); v != nil && !bytes.Equal(v, item.value) {
+			var current trackBase
+			if err := json.Unmarshal(v, &current); err != nil {
+				return err
+			}
+			for _, source := range current.SourcesRepository {
+				issue.base.AddSourceRepository(source.GetRepoOwner(), source.GetRepoName(), source.GetID())
+			}
+		}
+
+		issueJ, err := json.Marshal(issue.base)
+		if err != nil {
+			return err
+		}
+		return b.Put(item.key, issueJ)
+	})
+	if err != nil {
+		logger.Error().Err(err).Msgf("Error while saving issue %s", item.key)
+	}
+}